@@ -0,0 +1,151 @@
+// Package backup exports the complete configuration of Pull Zones to a versioned JSON archive and
+// restores zones from it, recreating deleted zones or undoing changes to existing ones.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// Version is the archive format version written by this package
+const Version = 1
+
+// ErrUnsupportedVersion is returned when reading an archive written by a newer version of this package
+var ErrUnsupportedVersion = errors.New("unsupported backup archive version")
+
+// Archive represents a backup of the configuration of one or more Pull Zones
+type Archive struct {
+	// Version is the archive format version
+	Version int `json:"Version"`
+
+	// CreatedAt is the time the archive was exported
+	CreatedAt time.Time `json:"CreatedAt"`
+
+	// Certificates indicates that the hostname certificates and their private keys are included
+	Certificates bool `json:"Certificates"`
+
+	// PullZones is the list of Pull Zones with all their settings, hostnames and edge rules
+	PullZones []resources.PullZone `json:"PullZones"`
+}
+
+// ExportOptions represents the options for exporting Pull Zones
+type ExportOptions struct {
+	// Certificates includes the certificates and private keys of the hostnames
+	Certificates bool
+}
+
+// Export returns an archive of the Pull Zones with the given IDs
+func Export(ctx context.Context, pullZones *resources.PullZoneService, ids []int64, opts *ExportOptions) (*Archive, error) {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+
+	archive := newArchive(opts)
+	for _, id := range ids {
+		zone, err := pullZones.Get(ctx, id, opts.Certificates)
+		if err != nil {
+			return nil, fmt.Errorf("export pull zone %d: %w", id, err)
+		}
+		archive.PullZones = append(archive.PullZones, *zone)
+	}
+
+	return archive, nil
+}
+
+// ExportAll returns an archive of every Pull Zone of the account
+func ExportAll(ctx context.Context, pullZones *resources.PullZoneService, opts *ExportOptions) (*Archive, error) {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+
+	zones, err := pullZones.ListAll(ctx, common.MaxPerPage, "", opts.Certificates)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := newArchive(opts)
+	archive.PullZones = zones
+	return archive, nil
+}
+
+// Find returns the archived Pull Zone with the given ID or case-insensitive name, or an error matching
+// common.ErrNotFound
+func (a *Archive) Find(ref string) (*resources.PullZone, error) {
+	id, idErr := strconv.ParseInt(ref, 10, 64)
+	for i := range a.PullZones {
+		zone := &a.PullZones[i]
+		if (idErr == nil && zone.Id == id) || strings.EqualFold(zone.Name, ref) {
+			return zone, nil
+		}
+	}
+	return nil, fmt.Errorf("pull zone %q in archive: %w", ref, common.ErrNotFound)
+}
+
+// Write writes the archive as indented JSON
+func (a *Archive) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(a)
+}
+
+// Read reads an archive, rejecting files that are not archives or were written by a newer version
+func Read(r io.Reader) (*Archive, error) {
+	var archive Archive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("failed to parse backup archive: %w", err)
+	}
+
+	switch {
+	case archive.Version == 0:
+		return nil, errors.New("failed to parse backup archive: missing Version")
+	case archive.Version > Version:
+		return nil, fmt.Errorf("%w %d, expected at most %d", ErrUnsupportedVersion, archive.Version, Version)
+	}
+
+	return &archive, nil
+}
+
+// Save writes the archive to a file that only the owner can read, since it holds security keys and
+// possibly private keys
+func (a *Archive) Save(path string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Load reads an archive from a file
+func Load(path string) (*Archive, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Read(file)
+}
+
+// newArchive returns an empty archive of the current version
+func newArchive(opts *ExportOptions) *Archive {
+	return &Archive{
+		Version:      Version,
+		CreatedAt:    time.Now().UTC(),
+		Certificates: opts.Certificates,
+	}
+}
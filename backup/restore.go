@@ -0,0 +1,268 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// RestoreOptions represents the options for restoring a Pull Zone from an archive
+type RestoreOptions struct {
+	// Name is the name of the zone when it has to be recreated, defaults to the archived name
+	Name string
+
+	// Hostnames adds the archived custom hostnames that the zone is missing
+	Hostnames bool
+
+	// Certificates uploads the archived certificates of hostnames that were added or have no certificate
+	Certificates bool
+
+	// KeepEdgeRules keeps edge rules of an existing zone that are not in the archive instead of deleting them
+	KeepEdgeRules bool
+}
+
+// RestoreReport represents the result of restoring a Pull Zone
+type RestoreReport struct {
+	// PullZone is the restored Pull Zone, with a new ID when the zone was recreated
+	PullZone *resources.PullZone
+
+	// Created indicates that the archived zone no longer existed and was recreated
+	Created bool
+
+	// Settings is the number of settings that were changed back, 0 for recreated zones
+	Settings int
+
+	// EdgeRules is the number of edge rules added or updated
+	EdgeRules int
+
+	// DeletedEdgeRules is the number of edge rules deleted because they are not in the archive
+	DeletedEdgeRules int
+
+	// Hostnames is the list of custom hostnames added
+	Hostnames []string
+
+	// Certificates is the list of hostnames whose certificate was uploaded
+	Certificates []string
+
+	// Skipped is the list of settings that could not be restored
+	Skipped []resources.SkippedSetting
+}
+
+// Restore restores a Pull Zone from its archived configuration. When no zone with the archived ID exists
+// any more, such as after an accidental delete, the zone is recreated with CreateFromTemplate and gets a
+// new ID. Otherwise the settings that differ are updated with Patch, edge rules are restored by Guid and
+// edge rules that are not in the archive are deleted unless KeepEdgeRules is set.
+//
+// The report is returned whenever the zone exists after the call; the error then joins the errors of
+// the settings that could not be restored.
+func Restore(ctx context.Context, pullZones *resources.PullZoneService, archived *resources.PullZone, opts *RestoreOptions) (*RestoreReport, error) {
+	if opts == nil {
+		opts = &RestoreOptions{}
+	}
+
+	live, err := pullZones.Get(ctx, archived.Id, false)
+	if errors.Is(err, common.ErrNotFound) {
+		return recreate(ctx, pullZones, archived, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RestoreReport{PullZone: live}
+
+	update := resources.DiffPullZones(live, archived)
+	if !update.IsEmpty() {
+		if _, err := pullZones.Patch(ctx, live.Id, live, archived); err != nil {
+			return report, fmt.Errorf("restore settings of pull zone %d: %w", live.Id, err)
+		}
+		report.Settings = countSet(update)
+	}
+
+	restoreEdgeRules(ctx, pullZones, live, archived, opts, report)
+	if opts.Hostnames {
+		restoreHostnames(ctx, pullZones, live, archived, opts, report)
+	}
+
+	if updated, err := pullZones.Get(ctx, live.Id, false); err == nil {
+		report.PullZone = updated
+	}
+
+	return report, report.err()
+}
+
+// recreate creates a deleted zone again from its archived configuration
+func recreate(ctx context.Context, pullZones *resources.PullZoneService, archived *resources.PullZone, opts *RestoreOptions) (*RestoreReport, error) {
+	name := opts.Name
+	if name == "" {
+		name = archived.Name
+	}
+
+	clone, err := pullZones.CreateFromTemplate(ctx, name, archived, &resources.CloneOptions{Hostnames: opts.Hostnames})
+	if clone == nil {
+		return nil, err
+	}
+
+	report := &RestoreReport{
+		PullZone:  clone.PullZone,
+		Created:   true,
+		EdgeRules: clone.EdgeRules,
+		Hostnames: clone.Hostnames,
+	}
+
+	uploaded := make(map[string]bool)
+	if opts.Certificates {
+		for _, hostname := range archived.Hostnames {
+			if contains(clone.Hostnames, hostname.Value) && restoreCertificate(ctx, pullZones, clone.PullZone.Id, hostname, report) {
+				uploaded["Hostname "+hostname.Value+" certificate"] = true
+			}
+		}
+	}
+	for _, skipped := range clone.Skipped {
+		if !uploaded[skipped.Setting] {
+			report.Skipped = append(report.Skipped, skipped)
+		}
+	}
+
+	// Every failed step of CreateFromTemplate, including a rejected settings update, is in clone.Skipped
+	return report, report.err()
+}
+
+// restoreEdgeRules adds or updates the archived edge rules by Guid and deletes the rules that were added since
+func restoreEdgeRules(ctx context.Context, pullZones *resources.PullZoneService, live, archived *resources.PullZone, opts *RestoreOptions, report *RestoreReport) {
+	current := make(map[string]resources.EdgeRule, len(live.EdgeRules))
+	for _, rule := range live.EdgeRules {
+		current[rule.Guid] = rule
+	}
+
+	wanted := make(map[string]bool, len(archived.EdgeRules))
+	for _, rule := range archived.EdgeRules {
+		wanted[rule.Guid] = true
+		if existing, ok := current[rule.Guid]; ok && sameEdgeRule(existing, rule) {
+			continue
+		}
+		if err := pullZones.AddOrUpdateEdgeRule(ctx, live.Id, rule.Options()); err != nil {
+			report.skip("EdgeRule "+rule.Guid, "the edge rule could not be restored", err)
+			continue
+		}
+		report.EdgeRules++
+	}
+
+	if opts.KeepEdgeRules {
+		return
+	}
+	for _, rule := range live.EdgeRules {
+		if wanted[rule.Guid] {
+			continue
+		}
+		if err := pullZones.DeleteEdgeRule(ctx, live.Id, rule.Guid); err != nil {
+			report.skip("EdgeRule "+rule.Guid, "the edge rule that is not in the archive could not be deleted", err)
+			continue
+		}
+		report.DeletedEdgeRules++
+	}
+}
+
+// restoreHostnames adds the missing custom hostnames with their Force SSL setting and certificate
+func restoreHostnames(ctx context.Context, pullZones *resources.PullZoneService, live, archived *resources.PullZone, opts *RestoreOptions, report *RestoreReport) {
+	for _, hostname := range archived.Hostnames {
+		if hostname.IsSystemHostname {
+			continue
+		}
+
+		current := live.FindHostname(hostname.Value)
+		if current == nil {
+			err := pullZones.AddHostname(ctx, live.Id, resources.AddHostnameOptions{Hostname: hostname.Value})
+			if err != nil {
+				report.skip("Hostname "+hostname.Value, "the hostname could not be added, it may belong to another Pull Zone", err)
+				continue
+			}
+			report.Hostnames = append(report.Hostnames, hostname.Value)
+			current = &resources.Hostname{Value: hostname.Value}
+		}
+
+		if opts.Certificates && !current.HasCertificate {
+			restoreCertificate(ctx, pullZones, live.Id, hostname, report)
+		}
+
+		if hostname.ForceSSL != current.ForceSSL {
+			err := pullZones.SetForceSSL(ctx, live.Id, resources.SetForceSSLOptions{Hostname: hostname.Value, ForceSSL: hostname.ForceSSL})
+			if err != nil {
+				report.skip("Hostname "+hostname.Value+" ForceSSL", "Force SSL could not be restored", err)
+			}
+		}
+	}
+}
+
+// restoreCertificate uploads the archived certificate of a hostname and reports whether it was uploaded
+func restoreCertificate(ctx context.Context, pullZones *resources.PullZoneService, id int64, hostname resources.Hostname, report *RestoreReport) bool {
+	if !hostname.HasCertificate {
+		return false
+	}
+
+	setting := "Hostname " + hostname.Value + " certificate"
+	if hostname.Certificate == "" || hostname.CertificateKey == "" {
+		report.skip(setting, "the archive does not include the certificate, export it with certificates", nil)
+		return false
+	}
+
+	err := pullZones.AddCertificate(ctx, id, resources.AddCertificateOptions{
+		Hostname:       hostname.Value,
+		Certificate:    hostname.Certificate,
+		CertificateKey: hostname.CertificateKey,
+	})
+	if err != nil {
+		report.skip(setting, "the certificate could not be uploaded", err)
+		return false
+	}
+
+	report.Certificates = append(report.Certificates, hostname.Value)
+	return true
+}
+
+// skip records a setting that could not be restored
+func (r *RestoreReport) skip(setting, reason string, err error) {
+	r.Skipped = append(r.Skipped, resources.SkippedSetting{Setting: setting, Reason: reason, Err: err})
+}
+
+// err joins the errors of the settings that could not be restored
+func (r *RestoreReport) err() error {
+	var errs []error
+	for _, skipped := range r.Skipped {
+		if skipped.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", skipped.Setting, skipped.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sameEdgeRule reports whether two edge rules have the same settings
+func sameEdgeRule(a, b resources.EdgeRule) bool {
+	return reflect.DeepEqual(a.Options(), b.Options())
+}
+
+// countSet returns the number of fields set in the update options
+func countSet(update resources.UpdatePullZoneOptions) int {
+	count := 0
+	v := reflect.ValueOf(update)
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).IsNil() {
+			count++
+		}
+	}
+	return count
+}
+
+// contains reports whether the list holds the value, ignoring case
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+// Package certificate parses, validates and uploads custom certificates for Pull Zone hostnames,
+// and scans Pull Zones for certificates that are about to expire
+package certificate
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+var (
+	// ErrKeyMismatch is returned when the private key does not belong to the certificate
+	ErrKeyMismatch = errors.New("certificate: private key does not match the certificate")
+
+	// ErrHostnameMismatch is returned when the certificate does not cover the hostname
+	ErrHostnameMismatch = errors.New("certificate: certificate does not cover the hostname")
+
+	// ErrExpired is returned when the certificate has expired
+	ErrExpired = errors.New("certificate: certificate has expired")
+
+	// ErrNotYetValid is returned when the validity period of the certificate has not started
+	ErrNotYetValid = errors.New("certificate: certificate is not valid yet")
+
+	// ErrInvalidChain is returned when a certificate of the chain is not signed by the next one
+	ErrInvalidChain = errors.New("certificate: certificate chain is out of order or incomplete")
+)
+
+// Bundle is a parsed certificate chain and its private key
+type Bundle struct {
+	// Leaf is the certificate of the hostname
+	Leaf *x509.Certificate
+
+	// Intermediates is the list of intermediate certificates following the leaf
+	Intermediates []*x509.Certificate
+
+	// Key is the private key of the leaf certificate
+	Key crypto.Signer
+
+	// CertificatePEM is the PEM encoded certificate chain
+	CertificatePEM []byte
+
+	// KeyPEM is the PEM encoded private key
+	KeyPEM []byte
+}
+
+// Parse parses a PEM encoded certificate chain, leaf first, and its PEM encoded private key.
+// The private key must match the leaf certificate.
+func Parse(certPEM, keyPEM []byte) (*Bundle, error) {
+	chain, err := ParseChain(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	public, ok := chain[0].PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !public.Equal(key.Public()) {
+		return nil, ErrKeyMismatch
+	}
+
+	return &Bundle{
+		Leaf:           chain[0],
+		Intermediates:  chain[1:],
+		Key:            key,
+		CertificatePEM: certPEM,
+		KeyPEM:         keyPEM,
+	}, nil
+}
+
+// ParseChain parses every certificate in PEM encoded data, in order
+func ParseChain(certPEM []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	rest := certPEM
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("certificate: invalid certificate %d: %w", len(chain)+1, err)
+		}
+		chain = append(chain, cert)
+	}
+
+	if len(chain) == 0 {
+		return nil, errors.New("certificate: no PEM encoded certificate found")
+	}
+	return chain, nil
+}
+
+// Validate checks that the certificate covers the hostname, is valid at the given time and that
+// every certificate of the chain is signed by the next one. All problems are returned together.
+func (b *Bundle) Validate(hostname string, now time.Time) error {
+	var errs []error
+
+	if err := b.Leaf.VerifyHostname(hostname); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %s is not in %v", ErrHostnameMismatch, hostname, b.Leaf.DNSNames))
+	}
+	if now.After(b.Leaf.NotAfter) {
+		errs = append(errs, fmt.Errorf("%w on %s", ErrExpired, b.Leaf.NotAfter.Format(time.DateOnly)))
+	}
+	if now.Before(b.Leaf.NotBefore) {
+		errs = append(errs, fmt.Errorf("%w before %s", ErrNotYetValid, b.Leaf.NotBefore.Format(time.DateOnly)))
+	}
+
+	chain := append([]*x509.Certificate{b.Leaf}, b.Intermediates...)
+	for i := 0; i < len(chain)-1; i++ {
+		if err := chain[i].CheckSignatureFrom(chain[i+1]); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %q is not signed by %q", ErrInvalidChain, chain[i].Subject.CommonName, chain[i+1].Subject.CommonName))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// NotAfter returns the expiry time of the leaf certificate
+func (b *Bundle) NotAfter() time.Time {
+	return b.Leaf.NotAfter
+}
+
+// ExpiresIn returns the time left until the leaf certificate expires, negative once it has expired
+func (b *Bundle) ExpiresIn(now time.Time) time.Duration {
+	return b.Leaf.NotAfter.Sub(now)
+}
+
+// AddCertificateOptions returns the options for uploading the bundle to a hostname
+func (b *Bundle) AddCertificateOptions(hostname string) resources.AddCertificateOptions {
+	return resources.AddCertificateOptions{
+		Hostname:       hostname,
+		Certificate:    base64.StdEncoding.EncodeToString(b.CertificatePEM),
+		CertificateKey: base64.StdEncoding.EncodeToString(b.KeyPEM),
+	}
+}
+
+// Upload parses and validates the certificate and key for the hostname and adds them to the Pull Zone
+func Upload(ctx context.Context, pullZones *resources.PullZoneService, pullZoneId int64, hostname string, certPEM, keyPEM []byte) (*Bundle, error) {
+	bundle, err := Parse(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	if err := bundle.Validate(hostname, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := pullZones.AddCertificate(ctx, pullZoneId, bundle.AddCertificateOptions(hostname)); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// DecodeHostnameCertificate decodes the certificate returned for a hostname, which is Base64 encoded
+// PEM or DER data. Only the leaf certificate is returned.
+func DecodeHostnameCertificate(encoded string) (*x509.Certificate, error) {
+	data := []byte(encoded)
+	if !bytes.Contains(data, []byte("-----BEGIN")) {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("certificate: invalid Base64 data: %w", err)
+		}
+		data = decoded
+	}
+
+	if bytes.Contains(data, []byte("-----BEGIN")) {
+		chain, err := ParseChain(data)
+		if err != nil {
+			return nil, err
+		}
+		return chain[0], nil
+	}
+
+	cert, err := x509.ParseCertificate(data)
+	if err != nil {
+		return nil, fmt.Errorf("certificate: invalid certificate: %w", err)
+	}
+	return cert, nil
+}
+
+// parsePrivateKey parses a PEM encoded PKCS #1, PKCS #8 or SEC 1 private key
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	rest := keyPEM
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("certificate: no PEM encoded private key found")
+		}
+
+		var key any
+		var err error
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			key, err = x509.ParseECPrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "ENCRYPTED PRIVATE KEY":
+			return nil, errors.New("certificate: encrypted private keys are not supported")
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("certificate: invalid private key: %w", err)
+		}
+
+		switch key := key.(type) {
+		case *rsa.PrivateKey:
+			return key, nil
+		case *ecdsa.PrivateKey:
+			return key, nil
+		case ed25519.PrivateKey:
+			return key, nil
+		}
+		return nil, fmt.Errorf("certificate: unsupported private key type %T", key)
+	}
+}
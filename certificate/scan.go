@@ -0,0 +1,97 @@
+package certificate
+
+import (
+	"context"
+	"crypto/x509"
+	"sort"
+	"time"
+
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// HostnameCertificate represents the certificate of a Pull Zone hostname
+type HostnameCertificate struct {
+	// PullZoneId is the ID of the Pull Zone
+	PullZoneId int64
+
+	// PullZoneName is the name of the Pull Zone
+	PullZoneName string
+
+	// Hostname is the hostname the certificate is configured for
+	Hostname string
+
+	// Certificate is the leaf certificate, nil when the API did not return it or it could not be decoded
+	Certificate *x509.Certificate
+
+	// Err is the error from decoding the certificate
+	Err error
+}
+
+// ExpiresWithin reports whether the certificate expires within the duration from now. Hostnames
+// whose certificate could not be decoded are reported as expiring so that they are not missed.
+func (h HostnameCertificate) ExpiresWithin(d time.Duration, now time.Time) bool {
+	if h.Certificate == nil {
+		return true
+	}
+	return h.Certificate.NotAfter.Before(now.Add(d))
+}
+
+// Scan lists the certificates of every hostname with a certificate across all Pull Zones.
+// System hostnames managed by bunny.net are skipped.
+func Scan(ctx context.Context, pullZones *resources.PullZoneService) ([]HostnameCertificate, error) {
+	zones, err := pullZones.ListAll(ctx, common.MaxPerPage, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var certificates []HostnameCertificate
+	for _, zone := range zones {
+		for _, hostname := range zone.Hostnames {
+			if hostname.IsSystemHostname || !hostname.HasCertificate {
+				continue
+			}
+
+			entry := HostnameCertificate{
+				PullZoneId:   zone.Id,
+				PullZoneName: zone.Name,
+				Hostname:     hostname.Value,
+			}
+			if hostname.Certificate != "" {
+				entry.Certificate, entry.Err = DecodeHostnameCertificate(hostname.Certificate)
+			}
+			certificates = append(certificates, entry)
+		}
+	}
+
+	return certificates, nil
+}
+
+// ScanExpiring lists the hostnames with certificates expiring within the given number of days,
+// soonest first. Hostnames whose certificate could not be read are listed last.
+func ScanExpiring(ctx context.Context, pullZones *resources.PullZoneService, days int) ([]HostnameCertificate, error) {
+	certificates, err := Scan(ctx, pullZones)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	within := time.Duration(days) * 24 * time.Hour
+
+	var expiring []HostnameCertificate
+	for _, certificate := range certificates {
+		if certificate.ExpiresWithin(within, now) {
+			expiring = append(expiring, certificate)
+		}
+	}
+
+	sort.SliceStable(expiring, func(i, j int) bool {
+		a, b := expiring[i].Certificate, expiring[j].Certificate
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return a.NotAfter.Before(b.NotAfter)
+	})
+
+	return expiring, nil
+}
@@ -0,0 +1,134 @@
+// Command bunny-ddns keeps A and AAAA records of a Bunny.net DNS zone pointed at the public addresses of the host.
+//
+// Usage:
+//
+//	BUNNYNET_API_KEY=... bunny-ddns -zone 12345 -name home -name @ -interval 5m
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/netip"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/ddns"
+)
+
+// names collects the repeatable -name flag
+type names []string
+
+func (n *names) String() string {
+	return strings.Join(*n, ",")
+}
+
+func (n *names) Set(value string) error {
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			*n = append(*n, name)
+		}
+	}
+	return nil
+}
+
+func main() {
+	var recordNames names
+	zoneId := flag.Int64("zone", 0, "ID of the DNS zone")
+	flag.Var(&recordNames, "name", "record name to update, relative to the zone (repeatable or comma-separated)")
+	ipv4 := flag.String("ipv4", "http:https://api.ipify.org", `IPv4 detector: "http:<url>", "iface:<name>" or "off"`)
+	ipv6 := flag.String("ipv6", "off", `IPv6 detector: "http:<url>", "iface:<name>" or "off"`)
+	ttl := flag.Int("ttl", 0, "TTL to set on the records in seconds, 0 keeps the current TTL")
+	stateFile := flag.String("state", "", "file recording the last written addresses")
+	create := flag.Bool("create", false, "add records that do not exist yet")
+	interval := flag.Duration("interval", 0, "update interval, 0 updates once and exits")
+	flag.Parse()
+
+	if *zoneId == 0 || len(recordNames) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Get API key from environment variable
+	apiKey := os.Getenv("BUNNYNET_API_KEY")
+	if apiKey == "" {
+		log.Fatal("BUNNYNET_API_KEY environment variable is not set")
+	}
+
+	config := ddns.Config{
+		ZoneId:        *zoneId,
+		Names:         recordNames,
+		Ttl:           int32(*ttl),
+		CreateMissing: *create,
+		StateFile:     *stateFile,
+	}
+
+	var err error
+	if config.IPv4, err = detector(*ipv4, ddns.IPv4); err != nil {
+		log.Fatalf("Invalid -ipv4: %v", err)
+	}
+	if config.IPv6, err = detector(*ipv6, ddns.IPv6); err != nil {
+		log.Fatalf("Invalid -ipv6: %v", err)
+	}
+	if config.IPv4 == nil && config.IPv6 == nil {
+		log.Fatal("Both detectors are off")
+	}
+
+	client := bunnynet.NewClient(apiKey, bunnynet.WithTimeout(30*time.Second))
+	updater := ddns.NewUpdater(client.DNSZone, config)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *interval <= 0 {
+		result, err := updater.Update(ctx)
+		report(result, err)
+		if err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := updater.Run(ctx, *interval, report); err != nil && ctx.Err() == nil {
+		log.Fatal(err)
+	}
+}
+
+// detector parses a detector flag, "off" disables the family
+func detector(spec string, family ddns.Family) (ddns.Detector, error) {
+	if spec == "" || spec == "off" {
+		return nil, nil
+	}
+	return ddns.ParseDetector(spec, family)
+}
+
+// report logs the outcome of an update run
+func report(result *ddns.Result, err error) {
+	if result != nil {
+		for _, change := range result.Changes {
+			from := "new record"
+			if change.From.IsValid() {
+				from = change.From.String()
+			}
+			log.Printf("Updated %s %s: %s -> %s", change.Type, change.Name, from, change.To)
+		}
+		if result.Cached {
+			log.Printf("Addresses unchanged (IPv4 %s, IPv6 %s)", addr(result.IPv4), addr(result.IPv6))
+		}
+	}
+	if err != nil {
+		log.Printf("Update failed: %v", err)
+	}
+}
+
+// addr formats an optional address
+func addr(a netip.Addr) string {
+	if !a.IsValid() {
+		return "-"
+	}
+	return a.String()
+}
@@ -0,0 +1,99 @@
+// Command bunny-drift reports changes made to Pull Zones since their snapshots were taken, such as edits
+// made in the dashboard. It exits with status 1 when any zone has drifted and 2 on errors.
+//
+// Usage:
+//
+//	BUNNYNET_API_KEY=... bunny-drift -save -id 12345 zones/site.json
+//	BUNNYNET_API_KEY=... bunny-drift zones/*.json
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/drift"
+)
+
+func main() {
+	save := flag.Bool("save", false, "write the live Pull Zone given by -id to the snapshot file instead of checking")
+	id := flag.Int64("id", 0, "ID of the Pull Zone to save")
+	ignore := flag.String("ignore", strings.Join(drift.DefaultIgnore, ","), "comma-separated list of fields to ignore")
+	flag.Parse()
+
+	if flag.NArg() == 0 || (*save && (*id == 0 || flag.NArg() != 1)) {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-ignore fields] snapshot...\n       %s -save -id <pull zone> snapshot\n", os.Args[0], os.Args[0])
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	log.SetFlags(0)
+
+	// Get API key from environment variable
+	apiKey := os.Getenv("BUNNYNET_API_KEY")
+	if apiKey == "" {
+		log.Print("BUNNYNET_API_KEY environment variable is not set")
+		os.Exit(2)
+	}
+
+	client := bunnynet.NewClient(apiKey, bunnynet.WithTimeout(30*time.Second))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *save {
+		zone, err := client.PullZone.Get(ctx, *id, false)
+		if err != nil {
+			log.Printf("Failed to get Pull Zone %d: %v", *id, err)
+			os.Exit(2)
+		}
+		if err := drift.SaveSnapshot(flag.Arg(0), zone); err != nil {
+			log.Printf("Failed to save snapshot: %v", err)
+			os.Exit(2)
+		}
+		log.Printf("Saved Pull Zone %s (%d) to %s", zone.Name, zone.Id, flag.Arg(0))
+		return
+	}
+
+	options := &drift.Options{Ignore: []string{}}
+	for _, field := range strings.Split(*ignore, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			options.Ignore = append(options.Ignore, field)
+		}
+	}
+
+	status := 0
+	for _, path := range flag.Args() {
+		snapshot, err := drift.LoadSnapshot(path)
+		if err != nil {
+			log.Print(err)
+			status = 2
+			continue
+		}
+
+		report, err := drift.Check(ctx, client.PullZone, snapshot, options)
+		if err != nil {
+			log.Printf("%s: failed to check Pull Zone %d: %v", path, snapshot.Id, err)
+			status = 2
+			continue
+		}
+
+		if err := report.Render(os.Stdout); err != nil {
+			log.Printf("%s: failed to write the report: %v", path, err)
+			status = 2
+			continue
+		}
+		if report.HasDrift() && status == 0 {
+			status = 1
+		}
+	}
+
+	os.Exit(status)
+}
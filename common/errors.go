@@ -2,10 +2,16 @@ package common
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
+// ErrNotFound is returned when a requested resource does not exist.
+// API responses with status 404 also match it with errors.Is.
+var ErrNotFound = errors.New("bunnynet: not found")
+
 // ErrorResponse represents an error response from the Bunny.net API
 type ErrorResponse struct {
 	// ErrorKey is a machine-readable error code
@@ -26,6 +32,11 @@ func (e *ErrorResponse) Error() string {
 	return fmt.Sprintf("[%d] %s: %s (%s)", e.StatusCode, e.ErrorKey, e.Message, e.Field)
 }
 
+// Is reports whether the error matches target, so that API 404 responses match ErrNotFound
+func (e *ErrorResponse) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
 // ParseErrorResponse attempts to parse an error response from the Bunny.net API
 func ParseErrorResponse(resp *http.Response) error {
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
@@ -72,3 +83,45 @@ func NewClientError(message string, err error) *ClientError {
 		Err:     err,
 	}
 }
+
+// FieldError describes a single field that failed client-side validation
+type FieldError struct {
+	// Field is the name of the invalid field
+	Field string
+
+	// Message describes why the field is invalid
+	Message string
+}
+
+// Error implements the error interface
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationError is returned when request options fail client-side validation
+// before a request is sent. It collects every invalid field.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldError := range e.Errors {
+		messages = append(messages, fieldError.Error())
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Add records an invalid field
+func (e *ValidationError) Add(field, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// ErrorOrNil returns the error if any field was recorded, otherwise nil
+func (e *ValidationError) ErrorOrNil() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
@@ -1,6 +1,8 @@
 // Package common provides common types and utilities for the Bunny.net API client
 package common
 
+import "strings"
+
 // PaginationOptions contains options for paginated API requests
 type PaginationOptions struct {
 	// Page is the page number to retrieve (starting from 1)
@@ -29,3 +31,14 @@ type PaginatedResponse[T any] struct {
 type RequestParams interface {
 	ToQueryParams() map[string]string
 }
+
+// NormalizeDomain returns a domain name in the form used to compare names: lowercase, without
+// surrounding space and without the trailing dot of a fully qualified name
+func NormalizeDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// Ptr returns a pointer to the given value, for setting optional fields in request options
+func Ptr[T any](v T) *T {
+	return &v
+}
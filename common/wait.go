@@ -0,0 +1,116 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultWaitInterval is the delay between polls when none is set
+	DefaultWaitInterval = 5 * time.Second
+
+	// DefaultMaxWaitInterval is the longest delay between polls when backoff is used and none is set
+	DefaultMaxWaitInterval = time.Minute
+)
+
+// ErrWaitTimeout is returned by Poll when the condition is not met within the timeout
+var ErrWaitTimeout = errors.New("bunnynet: timed out waiting for condition")
+
+// WaitOptions configures how Poll checks a condition
+type WaitOptions struct {
+	// Interval is the delay before the second poll, defaults to 5 seconds
+	Interval time.Duration
+
+	// Multiplier grows the delay after each poll for exponential backoff, values up to 1 keep it constant
+	Multiplier float64
+
+	// MaxInterval caps the delay when Multiplier is set, defaults to 1 minute
+	MaxInterval time.Duration
+
+	// Timeout is the maximum time to wait, 0 waits until the context is done
+	Timeout time.Duration
+
+	// Progress is called after every poll that did not meet the condition
+	Progress func(WaitProgress)
+}
+
+// WaitProgress describes a poll that did not meet the condition
+type WaitProgress struct {
+	// Attempt is the number of polls made so far
+	Attempt int
+
+	// Elapsed is the time since waiting started
+	Elapsed time.Duration
+
+	// Next is the delay until the next poll
+	Next time.Duration
+}
+
+// ConditionFunc checks whether a condition is met. Returning an error stops polling.
+type ConditionFunc func(ctx context.Context) (done bool, err error)
+
+// Poll checks the condition immediately and then after every interval until it is met, it returns an
+// error, the timeout elapses or the context is done. A timeout returns an error matching ErrWaitTimeout.
+func Poll(ctx context.Context, opts *WaitOptions, condition ConditionFunc) error {
+	if opts == nil {
+		opts = &WaitOptions{}
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultMaxWaitInterval
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, opts.Timeout, ErrWaitTimeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		done, err := condition(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return pollError(ctx, attempt)
+			}
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(WaitProgress{Attempt: attempt, Elapsed: time.Since(start), Next: interval})
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return pollError(ctx, attempt)
+		}
+
+		if opts.Multiplier > 1 {
+			interval = time.Duration(float64(interval) * opts.Multiplier)
+			if interval > maxInterval {
+				interval = maxInterval
+			}
+		}
+	}
+}
+
+// pollError returns the error for a context that ended while polling
+func pollError(ctx context.Context, attempts int) error {
+	if cause := context.Cause(ctx); errors.Is(cause, ErrWaitTimeout) {
+		return fmt.Errorf("%w after %d attempts", ErrWaitTimeout, attempts)
+	}
+	return ctx.Err()
+}
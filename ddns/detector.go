@@ -0,0 +1,225 @@
+// Package ddns keeps the A and AAAA records of a DNS zone pointed at the current public addresses of a host
+package ddns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// Family is an IP address family
+type Family int
+
+const (
+	// IPv4 is the IPv4 family, updated through A records
+	IPv4 Family = iota
+	// IPv6 is the IPv6 family, updated through AAAA records
+	IPv6
+)
+
+// String returns the name of the family
+func (f Family) String() string {
+	if f == IPv6 {
+		return "IPv6"
+	}
+	return "IPv4"
+}
+
+// RecordType returns the DNS record type holding addresses of the family
+func (f Family) RecordType() resources.DNSRecordType {
+	if f == IPv6 {
+		return resources.DNSRecordTypeAAAA
+	}
+	return resources.DNSRecordTypeA
+}
+
+// Contains reports whether the address belongs to the family
+func (f Family) Contains(addr netip.Addr) bool {
+	if f == IPv6 {
+		return addr.Is6() && !addr.Is4In6()
+	}
+	return addr.Unmap().Is4()
+}
+
+// network returns the network name used to dial over the family
+func (f Family) network() string {
+	if f == IPv6 {
+		return "tcp6"
+	}
+	return "tcp4"
+}
+
+// Detector detects the current public address of a host
+type Detector interface {
+	// Detect returns the current address
+	Detect(ctx context.Context) (netip.Addr, error)
+}
+
+// DetectorFunc adapts a function to the Detector interface
+type DetectorFunc func(ctx context.Context) (netip.Addr, error)
+
+// Detect calls f(ctx)
+func (f DetectorFunc) Detect(ctx context.Context) (netip.Addr, error) {
+	return f(ctx)
+}
+
+// InterfaceDetector detects the address assigned to a network interface. It suits IPv6 and hosts
+// with a public address on an interface, but not IPv4 behind NAT where the address is private.
+type InterfaceDetector struct {
+	// Interface is the name of the network interface, empty checks every interface
+	Interface string
+
+	// Family is the address family to detect
+	Family Family
+}
+
+// Detect returns the first public unicast address of the family on the interface
+func (d *InterfaceDetector) Detect(ctx context.Context) (netip.Addr, error) {
+	var interfaces []net.Interface
+	if d.Interface != "" {
+		iface, err := net.InterfaceByName(d.Interface)
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("ddns: %w", err)
+		}
+		interfaces = []net.Interface{*iface}
+	} else {
+		all, err := net.Interfaces()
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("ddns: %w", err)
+		}
+		interfaces = all
+	}
+
+	for _, iface := range interfaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			prefix, err := netip.ParsePrefix(addr.String())
+			if err != nil {
+				continue
+			}
+			if ip := prefix.Addr().Unmap(); d.Family.Contains(ip) && IsPublic(ip) {
+				return ip, nil
+			}
+		}
+	}
+
+	return netip.Addr{}, fmt.Errorf("ddns: no public %s address found on %s", d.Family, interfaceName(d.Interface))
+}
+
+// HTTPDetector detects the address by asking an echo endpoint that responds with the address of the client,
+// such as https://api.ipify.org or a server running EchoHandler. The request is made over the detector's family.
+type HTTPDetector struct {
+	// URL is the address of the echo endpoint
+	URL string
+
+	// Family is the address family to detect
+	Family Family
+
+	// Client is the HTTP client used for the request, defaults to a client dialing only the family
+	Client *http.Client
+}
+
+// Detect returns the address reported by the echo endpoint
+func (d *HTTPDetector) Detect(ctx context.Context) (netip.Addr, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.URL, nil)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("ddns: %w", err)
+	}
+
+	client := d.Client
+	if client == nil {
+		client = familyClient(d.Family)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("ddns: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return netip.Addr{}, fmt.Errorf("ddns: %s returned status %d", d.URL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 256))
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("ddns: %w", err)
+	}
+
+	addr, err := netip.ParseAddr(strings.TrimSpace(string(body)))
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("ddns: %s did not return an address: %w", d.URL, err)
+	}
+	addr = addr.Unmap()
+	if !d.Family.Contains(addr) {
+		return netip.Addr{}, fmt.Errorf("ddns: %s returned %s, expected an %s address", d.URL, addr, d.Family)
+	}
+
+	return addr, nil
+}
+
+// EchoHandler returns a handler that responds with the address of the client in plain text.
+// It can stand in for a public echo endpoint, for example on a server outside the local network.
+func EchoHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addrPort, err := netip.ParseAddrPort(r.RemoteAddr)
+		if err != nil {
+			http.Error(w, "unknown client address", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprintln(w, addrPort.Addr().Unmap())
+	})
+}
+
+// ParseDetector creates a detector from a specification, as used by command line flags:
+// "http:<url>" or a plain URL for an echo endpoint, "iface:<name>" for an interface and "iface:" for any interface.
+func ParseDetector(spec string, family Family) (Detector, error) {
+	switch {
+	case strings.HasPrefix(spec, "iface:"):
+		return &InterfaceDetector{Interface: strings.TrimPrefix(spec, "iface:"), Family: family}, nil
+	case strings.HasPrefix(spec, "http:") && !strings.HasPrefix(spec, "http://"):
+		return &HTTPDetector{URL: strings.TrimPrefix(spec, "http:"), Family: family}, nil
+	case strings.HasPrefix(spec, "http://"), strings.HasPrefix(spec, "https://"):
+		return &HTTPDetector{URL: spec, Family: family}, nil
+	}
+	return nil, errors.New("ddns: a detector must be an http:<url> or iface:<name> specification")
+}
+
+// IsPublic reports whether the address is a global unicast address outside the private and shared ranges
+func IsPublic(addr netip.Addr) bool {
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// sharedAddressSpace is the carrier-grade NAT range defined by RFC 6598
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// familyClient returns an HTTP client that only dials addresses of the family
+func familyClient(family Family) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, _, addr string) (net.Conn, error) {
+		return dialer.DialContext(ctx, family.network(), addr)
+	}
+	return &http.Client{Transport: transport, Timeout: 30 * time.Second}
+}
+
+// interfaceName returns a description of the interface for error messages
+func interfaceName(name string) string {
+	if name == "" {
+		return "any interface"
+	}
+	return name
+}
@@ -0,0 +1,99 @@
+package ddns
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// State records the addresses last written to the zone, so that unchanged addresses need no API calls
+type State struct {
+	// ZoneId is the ID of the DNS zone the addresses were written to
+	ZoneId int64 `json:"zoneId"`
+
+	// Names is the list of record names that were updated
+	Names []string `json:"names"`
+
+	// Ttl is the TTL set on the records
+	Ttl int32 `json:"ttl"`
+
+	// IPv4 is the IPv4 address written to the A records
+	IPv4 netip.Addr `json:"ipv4"`
+
+	// IPv6 is the IPv6 address written to the AAAA records
+	IPv6 netip.Addr `json:"ipv6"`
+
+	// UpdatedAt is the time the state was last changed
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// LoadState reads the state file, a missing file returns an empty state
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &State{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ddns: failed to read state: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("ddns: failed to parse state %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// Save writes the state file atomically
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("ddns: failed to write state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("ddns: failed to write state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("ddns: failed to write state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("ddns: failed to write state: %w", err)
+	}
+	return nil
+}
+
+// Address returns the recorded address of the family
+func (s *State) Address(family Family) netip.Addr {
+	if family == IPv6 {
+		return s.IPv6
+	}
+	return s.IPv4
+}
+
+// setAddress records the address of the family
+func (s *State) setAddress(family Family, addr netip.Addr) {
+	if family == IPv6 {
+		s.IPv6 = addr
+	} else {
+		s.IPv4 = addr
+	}
+}
+
+// matches reports whether the state was recorded for the same zone, names and TTL
+func (s *State) matches(config Config) bool {
+	return s.ZoneId == config.ZoneId && s.Ttl == config.Ttl && slices.Equal(s.Names, config.Names)
+}
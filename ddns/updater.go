@@ -0,0 +1,258 @@
+package ddns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// Config represents the records kept up to date by an Updater
+type Config struct {
+	// ZoneId is the ID of the DNS zone holding the records
+	ZoneId int64
+
+	// Names is the list of record names relative to the zone, "@" for the apex
+	Names []string
+
+	// IPv4 detects the address written to A records, nil leaves A records untouched
+	IPv4 Detector
+
+	// IPv6 detects the address written to AAAA records, nil leaves AAAA records untouched
+	IPv6 Detector
+
+	// Ttl is the TTL set on updated records, 0 keeps the current TTL
+	Ttl int32
+
+	// CreateMissing adds records that do not exist yet instead of reporting an error
+	CreateMissing bool
+
+	// StateFile is the path of the file recording the last written addresses, empty keeps the state in memory
+	StateFile string
+}
+
+// Change represents a record written by an Updater
+type Change struct {
+	// Name is the name of the record
+	Name string
+
+	// Type is the type of the record
+	Type resources.DNSRecordType
+
+	// RecordId is the ID of the record
+	RecordId int64
+
+	// From is the previous address, invalid for added records
+	From netip.Addr
+
+	// To is the new address
+	To netip.Addr
+}
+
+// Result represents the outcome of a single update run
+type Result struct {
+	// IPv4 is the detected IPv4 address, invalid when not detected
+	IPv4 netip.Addr
+
+	// IPv6 is the detected IPv6 address, invalid when not detected
+	IPv6 netip.Addr
+
+	// Changes is the list of records that were added or updated
+	Changes []Change
+
+	// Unchanged is the number of records that already had the detected address
+	Unchanged int
+
+	// Cached indicates that the detected addresses matched the state, so the zone was not fetched
+	Cached bool
+}
+
+// Updater keeps A and AAAA records pointed at the detected addresses
+type Updater struct {
+	dns    *resources.DNSZoneService
+	config Config
+	state  *State
+}
+
+// NewUpdater creates an Updater using the DNS zone service of a client
+func NewUpdater(dns *resources.DNSZoneService, config Config) *Updater {
+	return &Updater{dns: dns, config: config}
+}
+
+// Update detects the current addresses and updates the records whose address or TTL differs.
+// Families whose address matches the state file are skipped without calling the API.
+// A failing family does not prevent the other family from being updated; their errors are joined.
+func (u *Updater) Update(ctx context.Context) (*Result, error) {
+	if u.state == nil {
+		state := &State{}
+		if u.config.StateFile != "" {
+			loaded, err := LoadState(u.config.StateFile)
+			if err != nil {
+				return nil, err
+			}
+			state = loaded
+		}
+		if !state.matches(u.config) {
+			state = &State{ZoneId: u.config.ZoneId, Names: u.config.Names, Ttl: u.config.Ttl}
+		}
+		u.state = state
+	}
+
+	result := &Result{}
+	var errs []error
+	var pending []Family
+	detected := map[Family]netip.Addr{}
+
+	for _, family := range []Family{IPv4, IPv6} {
+		detector := u.detector(family)
+		if detector == nil {
+			continue
+		}
+
+		addr, err := detector.Detect(ctx)
+		if err == nil && !family.Contains(addr) {
+			err = fmt.Errorf("ddns: detector returned %s, expected an %s address", addr, family)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("detect %s: %w", family, err))
+			continue
+		}
+
+		addr = addr.Unmap()
+		if family == IPv6 {
+			result.IPv6 = addr
+		} else {
+			result.IPv4 = addr
+		}
+		detected[family] = addr
+		if u.state.Address(family) != addr {
+			pending = append(pending, family)
+		}
+	}
+
+	if len(pending) == 0 {
+		result.Cached = len(errs) == 0
+		return result, errors.Join(errs...)
+	}
+
+	zone, err := u.dns.Get(ctx, u.config.ZoneId)
+	if err != nil {
+		return result, errors.Join(append(errs, err)...)
+	}
+
+	changed := false
+	for _, family := range pending {
+		familyErrs := u.updateFamily(ctx, zone, family, detected[family], result)
+		if len(familyErrs) > 0 {
+			errs = append(errs, familyErrs...)
+			continue
+		}
+		u.state.setAddress(family, detected[family])
+		changed = true
+	}
+
+	if changed {
+		u.state.UpdatedAt = time.Now().UTC()
+		if u.config.StateFile != "" {
+			if err := u.state.Save(u.config.StateFile); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// Run calls Update immediately and then every interval until the context is done.
+// The report function, when set, receives the outcome of every run. The interval must be positive,
+// call Update directly to update once.
+func (u *Updater) Run(ctx context.Context, interval time.Duration, report func(*Result, error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("ddns: the update interval must be positive, got %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := u.Update(ctx)
+		if report != nil {
+			report(result, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// updateFamily points the records of every name at the address
+func (u *Updater) updateFamily(ctx context.Context, zone *resources.DNSZone, family Family, addr netip.Addr, result *Result) []error {
+	var errs []error
+	recordType := family.RecordType()
+	value := addr.String()
+
+	for _, name := range u.config.Names {
+		filter := &resources.DNSRecordFilter{Name: &name, Types: []resources.DNSRecordType{recordType}}
+
+		var matches []resources.DNSRecord
+		for _, record := range zone.Records {
+			if filter.Matches(record) {
+				matches = append(matches, record)
+			}
+		}
+
+		switch {
+		case len(matches) > 1:
+			errs = append(errs, fmt.Errorf("%s %s: found %d records, expected one", recordType, name, len(matches)))
+		case len(matches) == 0 && !u.config.CreateMissing:
+			errs = append(errs, fmt.Errorf("%s %s: record not found", recordType, name))
+		case len(matches) == 0:
+			record, err := u.dns.AddRecord(ctx, zone.Id, resources.AddDNSRecordOptions{
+				Type:  recordType,
+				Name:  name,
+				Value: value,
+				Ttl:   u.config.Ttl,
+			})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("add %s %s: %w", recordType, name, err))
+				continue
+			}
+			result.Changes = append(result.Changes, Change{Name: name, Type: recordType, RecordId: record.Id, To: addr})
+		default:
+			record := matches[0]
+			current, _ := netip.ParseAddr(record.Value)
+			update := resources.UpdateDNSRecordOptions{Id: record.Id}
+			if current.Unmap() != addr {
+				update.Value = &value
+			}
+			if u.config.Ttl != 0 && record.Ttl != u.config.Ttl {
+				update.Ttl = &u.config.Ttl
+			}
+			if update.Value == nil && update.Ttl == nil {
+				result.Unchanged++
+				continue
+			}
+			if err := u.dns.UpdateRecord(ctx, zone.Id, record.Id, update); err != nil {
+				errs = append(errs, fmt.Errorf("update %s %s: %w", recordType, name, err))
+				continue
+			}
+			result.Changes = append(result.Changes, Change{Name: name, Type: recordType, RecordId: record.Id, From: current, To: addr})
+		}
+	}
+
+	return errs
+}
+
+// detector returns the detector configured for the family
+func (u *Updater) detector(family Family) Detector {
+	if family == IPv6 {
+		return u.config.IPv6
+	}
+	return u.config.IPv4
+}
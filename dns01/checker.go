@@ -0,0 +1,77 @@
+package dns01
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+)
+
+// BunnyNameservers are the authoritative nameservers of Bunny DNS zones
+var BunnyNameservers = []string{"kiki.bunny.net:53", "coco.bunny.net:53"}
+
+// Checker checks whether a challenge record is visible
+type Checker interface {
+	// Check reports whether a TXT record with the value is visible at the fully qualified name
+	Check(ctx context.Context, fqdn, value string) (bool, error)
+}
+
+// CheckerFunc adapts a function to the Checker interface
+type CheckerFunc func(ctx context.Context, fqdn, value string) (bool, error)
+
+// Check calls f(ctx, fqdn, value)
+func (f CheckerFunc) Check(ctx context.Context, fqdn, value string) (bool, error) {
+	return f(ctx, fqdn, value)
+}
+
+// ResolverChecker returns a checker that requires every resolver to return the value.
+// DNS errors such as NXDOMAIN count as not yet propagated.
+func ResolverChecker(resolvers ...*net.Resolver) Checker {
+	return CheckerFunc(func(ctx context.Context, fqdn, value string) (bool, error) {
+		for _, resolver := range resolvers {
+			values, err := resolver.LookupTXT(ctx, fqdn)
+			var dnsErr *net.DNSError
+			if errors.As(err, &dnsErr) {
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			if !contains(values, value) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// NameserverChecker returns a checker that queries the nameservers directly, bypassing recursive resolvers
+// that may have cached the absence of the record. Addresses have the form "host:port".
+func NameserverChecker(nameservers ...string) Checker {
+	resolvers := make([]*net.Resolver, 0, len(nameservers))
+	for _, nameserver := range nameservers {
+		resolvers = append(resolvers, NameserverResolver(nameserver))
+	}
+	return ResolverChecker(resolvers...)
+}
+
+// NameserverResolver returns a resolver that sends every query to the nameserver at the address
+func NameserverResolver(addr string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, addr)
+		},
+	}
+}
+
+// contains reports whether the TXT values include the value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.TrimSpace(v) == value {
+			return true
+		}
+	}
+	return false
+}
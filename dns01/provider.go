@@ -0,0 +1,231 @@
+// Package dns01 implements an ACME DNS-01 challenge provider for Bunny DNS.
+//
+// Provider has the Present, CleanUp and Timeout methods of the lego challenge.Provider and
+// challenge.ProviderTimeout interfaces, so it can be passed to lego's SetDNS01Provider directly.
+package dns01
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+const (
+	// DefaultTtl is the TTL of challenge records when none is set
+	DefaultTtl = 60
+
+	// DefaultPropagationTimeout is the maximum time to wait for a record to propagate when none is set
+	DefaultPropagationTimeout = 2 * time.Minute
+
+	// DefaultPollingInterval is the delay between propagation checks when none is set
+	DefaultPollingInterval = 5 * time.Second
+)
+
+// Config represents the settings of a Provider
+type Config struct {
+	// ZoneId is the ID of the DNS zone holding the challenge records, 0 finds the zone owning each domain
+	ZoneId int64
+
+	// Ttl is the TTL of the challenge records, defaults to 60 seconds
+	Ttl int32
+
+	// PropagationTimeout is the maximum time to wait for a record to propagate, defaults to 2 minutes
+	PropagationTimeout time.Duration
+
+	// PollingInterval is the delay between propagation checks, defaults to 5 seconds
+	PollingInterval time.Duration
+
+	// Checker checks whether a record is visible, defaults to querying the Bunny nameservers directly
+	Checker Checker
+}
+
+// challengeRecord identifies a TXT record created by Present
+type challengeRecord struct {
+	zoneId   int64
+	recordId int64
+}
+
+// Provider presents and cleans up DNS-01 challenges. It is safe for concurrent use, so the challenges
+// of a certificate with several names can be solved in parallel.
+type Provider struct {
+	dns    *resources.DNSZoneService
+	config Config
+
+	mu      sync.Mutex
+	zones   map[string]*resources.DNSZone
+	records map[string]challengeRecord
+}
+
+// NewProvider creates a Provider using the DNS zone service of a client
+func NewProvider(dns *resources.DNSZoneService, config Config) *Provider {
+	if config.Ttl == 0 {
+		config.Ttl = DefaultTtl
+	}
+	if config.PropagationTimeout <= 0 {
+		config.PropagationTimeout = DefaultPropagationTimeout
+	}
+	if config.PollingInterval <= 0 {
+		config.PollingInterval = DefaultPollingInterval
+	}
+	if config.Checker == nil {
+		config.Checker = NameserverChecker(BunnyNameservers...)
+	}
+
+	return &Provider{
+		dns:     dns,
+		config:  config,
+		zones:   map[string]*resources.DNSZone{},
+		records: map[string]challengeRecord{},
+	}
+}
+
+// ChallengeRecord returns the fully qualified name and value of the TXT record for a DNS-01 challenge,
+// as defined in RFC 8555 section 8.4. Wildcard domains share the record of their base domain.
+func ChallengeRecord(domain, keyAuth string) (fqdn, value string) {
+	domain = strings.TrimSuffix(strings.TrimPrefix(domain, "*."), ".")
+	sum := sha256.Sum256([]byte(keyAuth))
+	return "_acme-challenge." + domain + ".", base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Present adds the challenge record and waits until it is visible
+func (p *Provider) Present(domain, token, keyAuth string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.PropagationTimeout+time.Minute)
+	defer cancel()
+	return p.PresentContext(ctx, domain, token, keyAuth)
+}
+
+// CleanUp deletes the challenge record
+func (p *Provider) CleanUp(domain, token, keyAuth string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	return p.CleanUpContext(ctx, domain, token, keyAuth)
+}
+
+// Timeout returns the propagation timeout and polling interval used by lego for its own checks
+func (p *Provider) Timeout() (timeout, interval time.Duration) {
+	return p.config.PropagationTimeout, p.config.PollingInterval
+}
+
+// PresentContext adds the challenge record and waits until the checker sees it
+func (p *Provider) PresentContext(ctx context.Context, domain, _, keyAuth string) error {
+	fqdn, value := ChallengeRecord(domain, keyAuth)
+
+	zone, err := p.zone(ctx, fqdn)
+	if err != nil {
+		return err
+	}
+	name, _ := zone.RecordName(fqdn)
+
+	record, err := p.dns.AddRecord(ctx, zone.Id, resources.AddDNSRecordOptions{
+		Type:  resources.DNSRecordTypeTXT,
+		Name:  name,
+		Value: value,
+		Ttl:   p.config.Ttl,
+	})
+	if err != nil {
+		return fmt.Errorf("dns01: failed to add TXT record %s: %w", fqdn, err)
+	}
+
+	p.mu.Lock()
+	p.records[recordKey(fqdn, value)] = challengeRecord{zoneId: zone.Id, recordId: record.Id}
+	p.mu.Unlock()
+
+	err = common.Poll(ctx, &common.WaitOptions{
+		Interval: p.config.PollingInterval,
+		Timeout:  p.config.PropagationTimeout,
+	}, func(ctx context.Context) (bool, error) {
+		return p.config.Checker.Check(ctx, fqdn, value)
+	})
+	if err != nil {
+		return fmt.Errorf("dns01: TXT record %s did not propagate: %w", fqdn, err)
+	}
+
+	return nil
+}
+
+// CleanUpContext deletes the challenge record. Records not created by this provider, for example
+// before a restart, are found by name and value.
+func (p *Provider) CleanUpContext(ctx context.Context, domain, _, keyAuth string) error {
+	fqdn, value := ChallengeRecord(domain, keyAuth)
+	key := recordKey(fqdn, value)
+
+	p.mu.Lock()
+	record, ok := p.records[key]
+	p.mu.Unlock()
+
+	if !ok {
+		zone, err := p.zone(ctx, fqdn)
+		if err != nil {
+			return err
+		}
+		name, _ := zone.RecordName(fqdn)
+		records, err := p.dns.ListRecords(ctx, zone.Id, &resources.DNSRecordFilter{
+			Name:  &name,
+			Types: []resources.DNSRecordType{resources.DNSRecordTypeTXT},
+			Value: &value,
+		})
+		if err != nil {
+			return fmt.Errorf("dns01: failed to find TXT record %s: %w", fqdn, err)
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		record = challengeRecord{zoneId: zone.Id, recordId: records[0].Id}
+	}
+
+	err := p.dns.DeleteRecord(ctx, record.zoneId, record.recordId)
+	if err != nil && !errors.Is(err, common.ErrNotFound) {
+		return fmt.Errorf("dns01: failed to delete TXT record %s: %w", fqdn, err)
+	}
+
+	p.mu.Lock()
+	delete(p.records, key)
+	p.mu.Unlock()
+
+	return nil
+}
+
+// zone returns the zone holding the record, caching lookups by name
+func (p *Provider) zone(ctx context.Context, fqdn string) (*resources.DNSZone, error) {
+	p.mu.Lock()
+	zone := p.zones[fqdn]
+	p.mu.Unlock()
+	if zone != nil {
+		return zone, nil
+	}
+
+	var err error
+	if p.config.ZoneId != 0 {
+		zone, err = p.dns.Get(ctx, p.config.ZoneId)
+	} else {
+		zone, err = p.dns.FindZoneForDomain(ctx, fqdn)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("dns01: failed to find the DNS zone for %s: %w", fqdn, err)
+	}
+	if _, ok := zone.RecordName(fqdn); !ok {
+		return nil, fmt.Errorf("dns01: %s is not part of the DNS zone %s", fqdn, zone.Domain)
+	}
+
+	// Records are not needed after the lookup, so they are not kept in the cache
+	zone.Records = nil
+
+	p.mu.Lock()
+	p.zones[fqdn] = zone
+	p.mu.Unlock()
+
+	return zone, nil
+}
+
+// recordKey identifies a challenge record by name and value
+func recordKey(fqdn, value string) string {
+	return fqdn + " " + value
+}
@@ -0,0 +1,86 @@
+package dnssec
+
+import (
+	"context"
+	"fmt"
+)
+
+// Resolver looks up the DS records published for a domain in its parent zone.
+// The standard library resolver cannot query DS records, so implementations typically wrap a DNS
+// library or a DNS-over-HTTPS endpoint and can use ParseDS to parse the answers.
+type Resolver interface {
+	// LookupDS returns the DS records of the domain, or an empty list when none are published
+	LookupDS(ctx context.Context, domain string) ([]DS, error)
+}
+
+// ResolverFunc adapts a function to the Resolver interface
+type ResolverFunc func(ctx context.Context, domain string) ([]DS, error)
+
+// LookupDS calls f(ctx, domain)
+func (f ResolverFunc) LookupDS(ctx context.Context, domain string) ([]DS, error) {
+	return f(ctx, domain)
+}
+
+// Status represents the state of the DS records in the parent zone
+type Status string
+
+const (
+	// StatusMissing means no DS record is published, the zone is not yet secured
+	StatusMissing Status = "missing"
+	// StatusMatch means a published DS record matches the key of the zone
+	StatusMatch Status = "match"
+	// StatusMismatch means DS records are published but none matches the key, so validation of the zone fails
+	StatusMismatch Status = "mismatch"
+)
+
+// CheckResult represents the result of comparing the DS records in the parent zone with the zone key
+type CheckResult struct {
+	// Status is the overall state of the delegation
+	Status Status
+
+	// Expected is the DS record that should be published
+	Expected DS
+
+	// Published is the list of DS records found in the parent zone
+	Published []DS
+
+	// Stale is the list of published DS records that do not match the key of the zone
+	Stale []DS
+}
+
+// Check compares the DS records published in the parent zone with the key of the zone.
+// Published records using a different digest type are verified by recomputing the digest from the key.
+func Check(ctx context.Context, resolver Resolver, info *Info) (*CheckResult, error) {
+	published, err := resolver.LookupDS(ctx, info.DS.Owner)
+	if err != nil {
+		return nil, fmt.Errorf("dnssec: failed to look up DS records for %s: %w", info.DS.Owner, err)
+	}
+
+	result := &CheckResult{Status: StatusMissing, Expected: info.DS, Published: published}
+	matched := false
+	for _, ds := range published {
+		if matchesKey(ds, info.Key) {
+			matched = true
+			continue
+		}
+		result.Stale = append(result.Stale, ds)
+	}
+
+	switch {
+	case matched:
+		result.Status = StatusMatch
+	case len(published) > 0:
+		result.Status = StatusMismatch
+	}
+
+	return result, nil
+}
+
+// matchesKey reports whether a DS record references the key with a correct digest
+func matchesKey(ds DS, key DNSKEY) bool {
+	computed, err := key.ComputeDS(ds.DigestType)
+	if err != nil {
+		return false
+	}
+	return computed.Equal(ds)
+}
@@ -0,0 +1,407 @@
+// Package dnssec verifies the DNSSEC information of a Bunny.net DNS zone and prepares it for registrars
+//
+// DNSZoneService.EnableDNSSec returns the DS record and the public key of the zone as strings.
+// Parse turns them into structured values, Verify recomputes the DS digest from the key to make
+// sure both agree, Render formats the DS record for registrar control panels and Check compares
+// it with the DS records published in the parent zone.
+package dnssec
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// Digest types defined for DS records
+const (
+	// DigestSHA1 is the SHA-1 digest type
+	DigestSHA1 uint8 = 1
+	// DigestSHA256 is the SHA-256 digest type
+	DigestSHA256 uint8 = 2
+	// DigestSHA384 is the SHA-384 digest type
+	DigestSHA384 uint8 = 4
+)
+
+// dnskeyProtocol is the only protocol value allowed in DNSKEY records
+const dnskeyProtocol = 3
+
+var (
+	// ErrDigestMismatch is returned when the DS digest does not match the digest computed from the key
+	ErrDigestMismatch = errors.New("dnssec: DS digest does not match the DNSKEY")
+
+	// ErrKeyTagMismatch is returned when the DS key tag does not match the key tag computed from the key
+	ErrKeyTagMismatch = errors.New("dnssec: DS key tag does not match the DNSKEY")
+
+	// ErrAlgorithmMismatch is returned when the DS algorithm does not match the key algorithm
+	ErrAlgorithmMismatch = errors.New("dnssec: DS algorithm does not match the DNSKEY")
+)
+
+// algorithmNames contains the mnemonics of the DNSSEC algorithms
+var algorithmNames = map[uint8]string{
+	5:  "RSASHA1",
+	7:  "RSASHA1-NSEC3-SHA1",
+	8:  "RSASHA256",
+	10: "RSASHA512",
+	13: "ECDSAP256SHA256",
+	14: "ECDSAP384SHA384",
+	15: "ED25519",
+	16: "ED448",
+}
+
+// digestNames contains the names of the DS digest types
+var digestNames = map[uint8]string{
+	DigestSHA1:   "SHA-1",
+	DigestSHA256: "SHA-256",
+	DigestSHA384: "SHA-384",
+}
+
+// AlgorithmName returns the mnemonic of a DNSSEC algorithm, such as "ECDSAP256SHA256"
+func AlgorithmName(algorithm uint8) string {
+	if name, ok := algorithmNames[algorithm]; ok {
+		return name
+	}
+	return strconv.Itoa(int(algorithm))
+}
+
+// DigestTypeName returns the name of a DS digest type, such as "SHA-256"
+func DigestTypeName(digestType uint8) string {
+	if name, ok := digestNames[digestType]; ok {
+		return name
+	}
+	return strconv.Itoa(int(digestType))
+}
+
+// DS represents a delegation signer record
+type DS struct {
+	// Owner is the domain of the zone, without a trailing dot
+	Owner string
+
+	// TTL is the time to live of the record
+	TTL uint32
+
+	// KeyTag is the key tag of the referenced DNSKEY
+	KeyTag uint16
+
+	// Algorithm is the DNSSEC algorithm of the referenced DNSKEY
+	Algorithm uint8
+
+	// DigestType is the digest algorithm
+	DigestType uint8
+
+	// Digest is the digest of the referenced DNSKEY
+	Digest []byte
+}
+
+// DigestHex returns the digest as upper-case hexadecimal
+func (d DS) DigestHex() string {
+	return strings.ToUpper(hex.EncodeToString(d.Digest))
+}
+
+// RDATA returns the record data in presentation format, such as "12345 13 2 ABCD..."
+func (d DS) RDATA() string {
+	return fmt.Sprintf("%d %d %d %s", d.KeyTag, d.Algorithm, d.DigestType, d.DigestHex())
+}
+
+// String returns the record in zone file presentation format, the TTL is left out when it is 0
+func (d DS) String() string {
+	if d.TTL == 0 {
+		return fmt.Sprintf("%s. IN DS %s", d.Owner, d.RDATA())
+	}
+	return fmt.Sprintf("%s. %d IN DS %s", d.Owner, d.TTL, d.RDATA())
+}
+
+// Equal reports whether two DS records reference the same key with the same digest, ignoring owner and TTL
+func (d DS) Equal(other DS) bool {
+	return d.KeyTag == other.KeyTag && d.Algorithm == other.Algorithm &&
+		d.DigestType == other.DigestType && strings.EqualFold(hex.EncodeToString(d.Digest), hex.EncodeToString(other.Digest))
+}
+
+// DNSKEY represents the public key-signing key of a zone
+type DNSKEY struct {
+	// Owner is the domain of the zone, without a trailing dot
+	Owner string
+
+	// Flags are the key flags, 257 for a key-signing key
+	Flags uint16
+
+	// Protocol is the protocol, always 3
+	Protocol uint8
+
+	// Algorithm is the DNSSEC algorithm of the key
+	Algorithm uint8
+
+	// PublicKey is the raw public key
+	PublicKey []byte
+}
+
+// RDATA returns the record data in presentation format, with the public key in base64
+func (k DNSKEY) RDATA() string {
+	return fmt.Sprintf("%d %d %d %s", k.Flags, k.Protocol, k.Algorithm, base64.StdEncoding.EncodeToString(k.PublicKey))
+}
+
+// String returns the record in zone file presentation format
+func (k DNSKEY) String() string {
+	return fmt.Sprintf("%s. IN DNSKEY %s", k.Owner, k.RDATA())
+}
+
+// wireRDATA returns the record data in wire format
+func (k DNSKEY) wireRDATA() []byte {
+	rdata := make([]byte, 4, 4+len(k.PublicKey))
+	binary.BigEndian.PutUint16(rdata, k.Flags)
+	rdata[2] = k.Protocol
+	rdata[3] = k.Algorithm
+	return append(rdata, k.PublicKey...)
+}
+
+// KeyTag computes the key tag of the key as defined in RFC 4034 Appendix B
+func (k DNSKEY) KeyTag() uint16 {
+	var sum uint32
+	for i, b := range k.wireRDATA() {
+		if i&1 == 1 {
+			sum += uint32(b)
+		} else {
+			sum += uint32(b) << 8
+		}
+	}
+	sum += sum >> 16 & 0xFFFF
+	return uint16(sum & 0xFFFF)
+}
+
+// ComputeDS computes the DS record for the key with the given digest type, as defined in RFC 4034 section 5.1.4
+func (k DNSKEY) ComputeDS(digestType uint8) (DS, error) {
+	owner, err := wireName(k.Owner)
+	if err != nil {
+		return DS{}, err
+	}
+	data := append(owner, k.wireRDATA()...)
+
+	var digest []byte
+	switch digestType {
+	case DigestSHA1:
+		sum := sha1.Sum(data)
+		digest = sum[:]
+	case DigestSHA256:
+		sum := sha256.Sum256(data)
+		digest = sum[:]
+	case DigestSHA384:
+		sum := sha512.Sum384(data)
+		digest = sum[:]
+	default:
+		return DS{}, fmt.Errorf("dnssec: unsupported digest type %d", digestType)
+	}
+
+	return DS{
+		Owner:      k.Owner,
+		KeyTag:     k.KeyTag(),
+		Algorithm:  k.Algorithm,
+		DigestType: digestType,
+		Digest:     digest,
+	}, nil
+}
+
+// Info is the parsed DNSSEC information of a zone
+type Info struct {
+	// DS is the DS record to publish in the parent zone
+	DS DS
+
+	// Key is the key-signing key of the zone
+	Key DNSKEY
+}
+
+// Parse parses the DNSSEC information returned by DNSZoneService.EnableDNSSec.
+// When domain is empty the owner of the DS record is used.
+func Parse(domain string, info *resources.DNSSecInfo) (*Info, error) {
+	if info == nil || !info.Enabled {
+		return nil, errors.New("dnssec: DNSSEC is not enabled for the zone")
+	}
+
+	var ds DS
+	if strings.TrimSpace(info.DsRecord) != "" {
+		parsed, err := ParseDS(info.DsRecord)
+		if err != nil {
+			return nil, err
+		}
+		ds = parsed
+	}
+
+	owner := common.NormalizeDomain(domain)
+	if owner == "" {
+		owner = ds.Owner
+	}
+	if owner == "" {
+		return nil, errors.New("dnssec: the zone domain is required when the DS record has no owner")
+	}
+	ds.Owner = owner
+
+	// The separate fields take precedence over the DS record text
+	if info.Digest != "" {
+		digest, err := hex.DecodeString(strings.ReplaceAll(info.Digest, " ", ""))
+		if err != nil {
+			return nil, fmt.Errorf("dnssec: invalid digest: %w", err)
+		}
+		ds.Digest = digest
+	}
+	if info.DigestType != "" {
+		digestType, err := parseDigestType(info.DigestType)
+		if err != nil {
+			return nil, err
+		}
+		ds.DigestType = digestType
+	}
+	if info.KeyTag != 0 {
+		ds.KeyTag = uint16(info.KeyTag)
+	}
+	if info.Algorithm != 0 {
+		ds.Algorithm = uint8(info.Algorithm)
+	}
+
+	publicKey, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(info.PublicKey), ""))
+	if err != nil {
+		return nil, fmt.Errorf("dnssec: invalid public key: %w", err)
+	}
+
+	key := DNSKEY{
+		Owner:     owner,
+		Flags:     uint16(info.Flags),
+		Protocol:  dnskeyProtocol,
+		Algorithm: ds.Algorithm,
+		PublicKey: publicKey,
+	}
+	if key.Flags == 0 {
+		key.Flags = 257
+	}
+
+	return &Info{DS: ds, Key: key}, nil
+}
+
+// Verify recomputes the DS record from the key and checks that it matches the DS record of the zone
+func (i *Info) Verify() error {
+	if i.DS.Algorithm != i.Key.Algorithm {
+		return fmt.Errorf("%w: DS has %d, DNSKEY has %d", ErrAlgorithmMismatch, i.DS.Algorithm, i.Key.Algorithm)
+	}
+
+	computed, err := i.Key.ComputeDS(i.DS.DigestType)
+	if err != nil {
+		return err
+	}
+	if computed.KeyTag != i.DS.KeyTag {
+		return fmt.Errorf("%w: DS has %d, computed %d", ErrKeyTagMismatch, i.DS.KeyTag, computed.KeyTag)
+	}
+	if !computed.Equal(i.DS) {
+		return fmt.Errorf("%w: DS has %s, computed %s", ErrDigestMismatch, i.DS.DigestHex(), computed.DigestHex())
+	}
+
+	return nil
+}
+
+// ParseDS parses a DS record in presentation format. The owner, TTL and class are optional,
+// so both "example.com. 3600 IN DS 12345 13 2 ABCD" and "12345 13 2 ABCD" are accepted.
+// The digest may be split over several fields.
+func ParseDS(s string) (DS, error) {
+	fields := strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(s))
+
+	var ds DS
+	index := -1
+	for i, field := range fields {
+		if strings.EqualFold(field, "DS") {
+			index = i
+			break
+		}
+	}
+
+	if index >= 0 {
+		for _, field := range fields[:index] {
+			switch {
+			case strings.EqualFold(field, "IN"):
+			case isNumber(field):
+				ttl, _ := strconv.ParseUint(field, 10, 32)
+				ds.TTL = uint32(ttl)
+			default:
+				ds.Owner = common.NormalizeDomain(field)
+			}
+		}
+		fields = fields[index+1:]
+	}
+
+	if len(fields) < 4 {
+		return DS{}, fmt.Errorf("dnssec: invalid DS record %q", s)
+	}
+
+	keyTag, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return DS{}, fmt.Errorf("dnssec: invalid DS key tag %q", fields[0])
+	}
+	algorithm, err := strconv.ParseUint(fields[1], 10, 8)
+	if err != nil {
+		return DS{}, fmt.Errorf("dnssec: invalid DS algorithm %q", fields[1])
+	}
+	digestType, err := parseDigestType(fields[2])
+	if err != nil {
+		return DS{}, err
+	}
+	digest, err := hex.DecodeString(strings.Join(fields[3:], ""))
+	if err != nil {
+		return DS{}, fmt.Errorf("dnssec: invalid DS digest: %w", err)
+	}
+
+	ds.KeyTag = uint16(keyTag)
+	ds.Algorithm = uint8(algorithm)
+	ds.DigestType = digestType
+	ds.Digest = digest
+	return ds, nil
+}
+
+// parseDigestType parses a digest type from its number or its name, such as "2" or "SHA-256"
+func parseDigestType(s string) (uint8, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseUint(s, 10, 8); err == nil {
+		return uint8(n), nil
+	}
+
+	normalized := strings.ReplaceAll(strings.ToUpper(s), "-", "")
+	for digestType, name := range digestNames {
+		if strings.ReplaceAll(name, "-", "") == normalized {
+			return digestType, nil
+		}
+	}
+	return 0, fmt.Errorf("dnssec: unknown digest type %q", s)
+}
+
+// wireName encodes a domain name in canonical wire format
+func wireName(domain string) ([]byte, error) {
+	domain = common.NormalizeDomain(domain)
+	var wire []byte
+	if domain != "" {
+		for _, label := range strings.Split(domain, ".") {
+			if label == "" || len(label) > 63 {
+				return nil, fmt.Errorf("dnssec: invalid domain %q", domain)
+			}
+			wire = append(wire, byte(len(label)))
+			wire = append(wire, label...)
+		}
+	}
+	return append(wire, 0), nil
+}
+
+// isNumber reports whether the string only contains digits
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
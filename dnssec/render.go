@@ -0,0 +1,67 @@
+package dnssec
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Format represents the way a registrar expects DNSSEC information to be entered
+type Format int
+
+const (
+	// FormatZone renders the DS record in zone file presentation format, for registrars that accept a full record
+	FormatZone Format = iota
+	// FormatRDATA renders only the DS record data, such as "12345 13 2 ABCD..."
+	FormatRDATA
+	// FormatFields renders one labelled line per field, for registrars with separate form fields
+	FormatFields
+	// FormatDNSKEY renders the DNSKEY record data, for registries that take the public key instead of a DS record
+	FormatDNSKEY
+	// FormatJSON renders the DS fields as a JSON object, for registrar APIs
+	FormatJSON
+)
+
+// dsJSON is the JSON representation of a DS record used by FormatJSON
+type dsJSON struct {
+	KeyTag     uint16 `json:"keyTag"`
+	Algorithm  uint8  `json:"algorithm"`
+	DigestType uint8  `json:"digestType"`
+	Digest     string `json:"digest"`
+	Flags      uint16 `json:"flags"`
+	PublicKey  string `json:"publicKey"`
+}
+
+// Render formats the DNSSEC information for a registrar
+func (i *Info) Render(format Format) (string, error) {
+	switch format {
+	case FormatZone:
+		return i.DS.String(), nil
+	case FormatRDATA:
+		return i.DS.RDATA(), nil
+	case FormatFields:
+		var b strings.Builder
+		fmt.Fprintf(&b, "Key Tag: %d\n", i.DS.KeyTag)
+		fmt.Fprintf(&b, "Algorithm: %d (%s)\n", i.DS.Algorithm, AlgorithmName(i.DS.Algorithm))
+		fmt.Fprintf(&b, "Digest Type: %d (%s)\n", i.DS.DigestType, DigestTypeName(i.DS.DigestType))
+		fmt.Fprintf(&b, "Digest: %s\n", i.DS.DigestHex())
+		return b.String(), nil
+	case FormatDNSKEY:
+		return i.Key.RDATA(), nil
+	case FormatJSON:
+		data, err := json.Marshal(dsJSON{
+			KeyTag:     i.DS.KeyTag,
+			Algorithm:  i.DS.Algorithm,
+			DigestType: i.DS.DigestType,
+			Digest:     i.DS.DigestHex(),
+			Flags:      i.Key.Flags,
+			PublicKey:  base64.StdEncoding.EncodeToString(i.Key.PublicKey),
+		})
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("dnssec: unknown format %d", format)
+}
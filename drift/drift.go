@@ -0,0 +1,269 @@
+// Package drift detects changes made to Pull Zones outside of version control, such as in the dashboard,
+// by comparing a live zone with a stored snapshot field by field.
+package drift
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// ChangeKind represents the kind of a field-level change
+type ChangeKind string
+
+const (
+	// ChangeKindAdded is a list entry, hostname or edge rule that was added
+	ChangeKindAdded ChangeKind = "added"
+	// ChangeKindRemoved is a list entry, hostname or edge rule that was removed
+	ChangeKindRemoved ChangeKind = "removed"
+	// ChangeKindModified is a field whose value changed
+	ChangeKindModified ChangeKind = "modified"
+)
+
+// DefaultIgnore is the list of fields ignored by Compare when no options are given, they change without
+// anyone editing the zone
+var DefaultIgnore = []string{"MonthlyBandwidthUsed", "MonthlyCharges"}
+
+// Change represents a single difference between two Pull Zones
+type Change struct {
+	// Path is the field that changed, such as "EnableWebPVary", "Hostnames[cdn.example.com].ForceSSL"
+	// or "EdgeRules[6d3f...].ActionParameter1"
+	Path string
+
+	// Kind is the kind of change
+	Kind ChangeKind
+
+	// Old is the previous value, nil for additions
+	Old interface{}
+
+	// New is the current value, nil for removals
+	New interface{}
+}
+
+// Options represents the options for comparing Pull Zones
+type Options struct {
+	// Ignore is the list of top-level fields to skip, defaults to DefaultIgnore
+	Ignore []string
+}
+
+// Compare returns the field-level differences from before to after. Hostnames are matched by value and
+// edge rules by Guid, or by description when a rule has no Guid; string lists such as BlockedIps are
+// compared as sets and report every added and removed entry. Changes are ordered by path.
+func Compare(before, after *resources.PullZone, opts *Options) []Change {
+	ignore := DefaultIgnore
+	if opts != nil && opts.Ignore != nil {
+		ignore = opts.Ignore
+	}
+	ignored := make(map[string]bool, len(ignore))
+	for _, name := range ignore {
+		ignored[name] = true
+	}
+
+	var changes []Change
+
+	bv := reflect.ValueOf(before).Elem()
+	av := reflect.ValueOf(after).Elem()
+	for i := 0; i < bv.NumField(); i++ {
+		name := bv.Type().Field(i).Name
+		if ignored[name] {
+			continue
+		}
+
+		switch name {
+		case "Hostnames":
+			changes = append(changes, compareHostnames(before.Hostnames, after.Hostnames)...)
+		case "EdgeRules":
+			changes = append(changes, compareEdgeRules(before.EdgeRules, after.EdgeRules)...)
+		default:
+			changes = append(changes, compareValues(name, bv.Field(i), av.Field(i))...)
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// compareValues compares a field, treating string lists as sets and nil and empty lists as equal
+func compareValues(path string, before, after reflect.Value) []Change {
+	if before.Kind() == reflect.Slice && before.Type().Elem().Kind() == reflect.String {
+		return compareStrings(path, before.Interface().([]string), after.Interface().([]string))
+	}
+	if before.Kind() == reflect.Slice && before.Len() == 0 && after.Len() == 0 {
+		return nil
+	}
+	if reflect.DeepEqual(before.Interface(), after.Interface()) {
+		return nil
+	}
+	return []Change{{Path: path, Kind: ChangeKindModified, Old: before.Interface(), New: after.Interface()}}
+}
+
+// compareStrings reports the entries added to and removed from a string list
+func compareStrings(path string, before, after []string) []Change {
+	inBefore := make(map[string]bool, len(before))
+	for _, value := range before {
+		inBefore[value] = true
+	}
+	inAfter := make(map[string]bool, len(after))
+	for _, value := range after {
+		inAfter[value] = true
+	}
+
+	var changes []Change
+	for _, value := range before {
+		if !inAfter[value] {
+			changes = append(changes, Change{Path: path, Kind: ChangeKindRemoved, Old: value})
+			inAfter[value] = true
+		}
+	}
+	for _, value := range after {
+		if !inBefore[value] {
+			changes = append(changes, Change{Path: path, Kind: ChangeKindAdded, New: value})
+			inBefore[value] = true
+		}
+	}
+	return changes
+}
+
+// compareHostnames matches hostnames by value and compares their settings
+func compareHostnames(before, after []resources.Hostname) []Change {
+	key := func(h resources.Hostname) string { return strings.ToLower(h.Value) }
+
+	byKey := make(map[string]resources.Hostname, len(after))
+	for _, hostname := range after {
+		byKey[key(hostname)] = hostname
+	}
+
+	var changes []Change
+	matched := make(map[string]bool, len(before))
+	for _, previous := range before {
+		k := key(previous)
+		current, ok := byKey[k]
+		if !ok {
+			changes = append(changes, Change{Path: "Hostnames", Kind: ChangeKindRemoved, Old: previous.Value})
+			continue
+		}
+		matched[k] = true
+
+		path := fmt.Sprintf("Hostnames[%s]", previous.Value)
+		changes = append(changes, compareStruct(path, reflect.ValueOf(previous), reflect.ValueOf(current), "Id", "Value")...)
+	}
+	for _, hostname := range after {
+		if !matched[key(hostname)] {
+			changes = append(changes, Change{Path: "Hostnames", Kind: ChangeKindAdded, New: hostname.Value})
+		}
+	}
+	return changes
+}
+
+// compareEdgeRules matches edge rules by Guid and compares their settings. A rule without a Guid, such as
+// one from a hand-written snapshot, is matched in order with the next unmatched rule of the same description.
+func compareEdgeRules(before, after []resources.EdgeRule) []Change {
+	pairs := matchEdgeRules(before, after)
+
+	var changes []Change
+	matched := make(map[int]bool, len(pairs))
+	for i, previous := range before {
+		j, ok := pairs[i]
+		if !ok {
+			changes = append(changes, Change{Path: "EdgeRules", Kind: ChangeKindRemoved, Old: describeEdgeRule(previous)})
+			continue
+		}
+		matched[j] = true
+
+		path := fmt.Sprintf("EdgeRules[%s]", edgeRuleLabel(previous, after[j], i))
+		changes = append(changes, compareStruct(path, reflect.ValueOf(previous), reflect.ValueOf(after[j]), "Guid")...)
+	}
+	for j, rule := range after {
+		if !matched[j] {
+			changes = append(changes, Change{Path: "EdgeRules", Kind: ChangeKindAdded, New: describeEdgeRule(rule)})
+		}
+	}
+	return changes
+}
+
+// matchEdgeRules returns the index in after of the rule matching each rule in before. Rules are first
+// matched by Guid, the remaining rules lacking a Guid on either side are then matched by description.
+func matchEdgeRules(before, after []resources.EdgeRule) map[int]int {
+	pairs := make(map[int]int)
+	taken := make(map[int]bool)
+
+	byGuid := make(map[string]int, len(after))
+	for j, rule := range after {
+		if rule.Guid != "" {
+			byGuid[rule.Guid] = j
+		}
+	}
+	for i, rule := range before {
+		if j, ok := byGuid[rule.Guid]; ok && rule.Guid != "" && !taken[j] {
+			pairs[i] = j
+			taken[j] = true
+		}
+	}
+
+	for i, rule := range before {
+		if _, ok := pairs[i]; ok {
+			continue
+		}
+		for j, candidate := range after {
+			if taken[j] || (rule.Guid != "" && candidate.Guid != "") || rule.Description != candidate.Description {
+				continue
+			}
+			pairs[i] = j
+			taken[j] = true
+			break
+		}
+	}
+	return pairs
+}
+
+// edgeRuleLabel names a matched rule in a change path by its Guid, its description or its position
+func edgeRuleLabel(before, after resources.EdgeRule, index int) string {
+	switch {
+	case before.Guid != "":
+		return before.Guid
+	case after.Guid != "":
+		return after.Guid
+	case before.Description != "":
+		return before.Description
+	default:
+		return "#" + strconv.Itoa(index)
+	}
+}
+
+// compareStruct compares the fields of two values of the same struct type, skipping the identity fields
+func compareStruct(path string, before, after reflect.Value, skip ...string) []Change {
+	var changes []Change
+	for i := 0; i < before.NumField(); i++ {
+		name := before.Type().Field(i).Name
+		if contains(skip, name) {
+			continue
+		}
+		changes = append(changes, compareValues(path+"."+name, before.Field(i), after.Field(i))...)
+	}
+	return changes
+}
+
+// describeEdgeRule names an added or removed edge rule by its Guid and description
+func describeEdgeRule(rule resources.EdgeRule) string {
+	if rule.Description == "" {
+		return rule.Guid
+	}
+	if rule.Guid == "" {
+		return rule.Description
+	}
+	return fmt.Sprintf("%s (%s)", rule.Guid, rule.Description)
+}
+
+// contains reports whether the list holds the value
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
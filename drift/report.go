@@ -0,0 +1,110 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// changeSymbols maps change kinds to the prefixes used when rendering a report
+var changeSymbols = map[ChangeKind]string{
+	ChangeKindAdded:    "+",
+	ChangeKindModified: "~",
+	ChangeKindRemoved:  "-",
+}
+
+// Report represents the drift of a live Pull Zone from its snapshot
+type Report struct {
+	// PullZoneId is the ID of the Pull Zone
+	PullZoneId int64
+
+	// Name is the name of the Pull Zone
+	Name string
+
+	// Changes is the list of changes from the snapshot to the live zone
+	Changes []Change
+}
+
+// HasDrift reports whether the live zone differs from the snapshot
+func (r *Report) HasDrift() bool {
+	return len(r.Changes) > 0
+}
+
+// Summary returns the number of additions, modifications and removals in the report
+func (r *Report) Summary() (added, modified, removed int) {
+	for _, change := range r.Changes {
+		switch change.Kind {
+		case ChangeKindAdded:
+			added++
+		case ChangeKindModified:
+			modified++
+		case ChangeKindRemoved:
+			removed++
+		}
+	}
+	return added, modified, removed
+}
+
+// Check fetches the live Pull Zone with the ID of the snapshot and compares it with the snapshot
+func Check(ctx context.Context, pullZones *resources.PullZoneService, snapshot *resources.PullZone, opts *Options) (*Report, error) {
+	live, err := pullZones.Get(ctx, snapshot.Id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Report{
+		PullZoneId: snapshot.Id,
+		Name:       live.Name,
+		Changes:    Compare(snapshot, live, opts),
+	}, nil
+}
+
+// Render writes a human-readable report of the drift
+func (r *Report) Render(w io.Writer) error {
+	if !r.HasDrift() {
+		_, err := fmt.Fprintf(w, "Pull Zone %s (%d) matches the snapshot.\n", r.Name, r.PullZoneId)
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Pull Zone %s (%d) has drifted from the snapshot:\n\n", r.Name, r.PullZoneId)
+
+	for _, change := range r.Changes {
+		switch change.Kind {
+		case ChangeKindAdded:
+			fmt.Fprintf(&b, "  %s %s: %s\n", changeSymbols[change.Kind], change.Path, formatValue(change.New))
+		case ChangeKindRemoved:
+			fmt.Fprintf(&b, "  %s %s: %s\n", changeSymbols[change.Kind], change.Path, formatValue(change.Old))
+		default:
+			fmt.Fprintf(&b, "  %s %s: %s => %s\n", changeSymbols[change.Kind], change.Path, formatValue(change.Old), formatValue(change.New))
+		}
+	}
+
+	added, modified, removed := r.Summary()
+	fmt.Fprintf(&b, "\nDrift: %d added, %d modified, %d removed.\n", added, modified, removed)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// String returns the rendered report
+func (r *Report) String() string {
+	var b strings.Builder
+	_ = r.Render(&b)
+	return b.String()
+}
+
+// formatValue formats a field value for display
+func formatValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return "(none)"
+	case string:
+		return fmt.Sprintf("%q", value)
+	default:
+		return fmt.Sprintf("%+v", value)
+	}
+}
@@ -0,0 +1,80 @@
+package drift
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/resources"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadSnapshot reads a Pull Zone snapshot from a JSON or YAML file, chosen by the .yaml or .yml extension
+func LoadSnapshot(path string) (*resources.PullZone, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	zone, err := ParseSnapshot(data, isYAML(path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return zone, nil
+}
+
+// ParseSnapshot parses a Pull Zone snapshot. YAML snapshots use the same field names as the API's JSON,
+// such as "OriginUrl" and "EdgeRules".
+func ParseSnapshot(data []byte, yamlFormat bool) (*resources.PullZone, error) {
+	if yamlFormat {
+		var document interface{}
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+		}
+		converted, err := json.Marshal(document)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+		}
+		data = converted
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var zone resources.PullZone
+	if err := decoder.Decode(&zone); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	return &zone, nil
+}
+
+// SaveSnapshot writes a Pull Zone snapshot to a JSON or YAML file, chosen by the .yaml or .yml extension
+func SaveSnapshot(path string, zone *resources.PullZone) error {
+	data, err := json.MarshalIndent(zone, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if isYAML(path) {
+		var document interface{}
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return err
+		}
+		if data, err = yaml.Marshal(document); err != nil {
+			return err
+		}
+	} else {
+		data = append(data, '\n')
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// isYAML reports whether a file name has a YAML extension
+func isYAML(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,224 @@
+// Package edgerule provides constructors for Bunny.net pull zone edge rules
+//
+// Rules are built from an action and one or more triggers:
+//
+//	options, err := edgerule.Redirect("https://example.com/new", 301).
+//		When(edgerule.URLMatches("*/old/*")).
+//		Describe("Redirect old pages").
+//		Build()
+package edgerule
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// Rule builds the options for adding or updating an edge rule
+type Rule struct {
+	options resources.AddOrUpdateEdgeRuleOptions
+}
+
+// New creates an enabled rule with the given action and action parameters
+func New(action resources.EdgeRuleActionType, parameter1, parameter2 string) *Rule {
+	return &Rule{
+		options: resources.AddOrUpdateEdgeRuleOptions{
+			ActionType:       action,
+			ActionParameter1: parameter1,
+			ActionParameter2: parameter2,
+			Enabled:          true,
+		},
+	}
+}
+
+// ForceSSL creates a rule that redirects HTTP requests to HTTPS
+func ForceSSL() *Rule {
+	return New(resources.EdgeRuleActionTypeForceSSL, "", "")
+}
+
+// Redirect creates a rule that redirects to the given URL, statusCode 0 uses the API default
+func Redirect(url string, statusCode int) *Rule {
+	return New(resources.EdgeRuleActionTypeRedirect, url, formatOptionalInt(statusCode))
+}
+
+// OriginURL creates a rule that fetches the request from a different origin URL
+func OriginURL(url string) *Rule {
+	return New(resources.EdgeRuleActionTypeOriginUrl, url, "")
+}
+
+// OriginStorage creates a rule that serves the request from a storage zone
+func OriginStorage(storageZoneId int64) *Rule {
+	return New(resources.EdgeRuleActionTypeOriginStorage, strconv.FormatInt(storageZoneId, 10), "")
+}
+
+// Block creates a rule that blocks the request
+func Block() *Rule {
+	return New(resources.EdgeRuleActionTypeBlockRequest, "", "")
+}
+
+// SetResponseHeader creates a rule that sets a response header
+func SetResponseHeader(name, value string) *Rule {
+	return New(resources.EdgeRuleActionTypeSetResponseHeader, name, value)
+}
+
+// SetRequestHeader creates a rule that sets a request header sent to the origin
+func SetRequestHeader(name, value string) *Rule {
+	return New(resources.EdgeRuleActionTypeSetRequestHeader, name, value)
+}
+
+// OverrideCacheTime creates a rule that overrides the edge cache time
+func OverrideCacheTime(d time.Duration) *Rule {
+	return New(resources.EdgeRuleActionTypeOverrideCacheTime, formatSeconds(d), "")
+}
+
+// OverrideCacheTimePublic creates a rule that overrides the cache time sent to the client
+func OverrideCacheTimePublic(d time.Duration) *Rule {
+	return New(resources.EdgeRuleActionTypeOverrideCacheTimePublic, formatSeconds(d), "")
+}
+
+// OverrideBrowserCacheTime creates a rule that overrides the browser cache time
+func OverrideBrowserCacheTime(d time.Duration) *Rule {
+	return New(resources.EdgeRuleActionTypeOverrideBrowserCacheTime, formatSeconds(d), "")
+}
+
+// ForceDownload creates a rule that forces the response to be downloaded
+func ForceDownload() *Rule {
+	return New(resources.EdgeRuleActionTypeForceDownload, "", "")
+}
+
+// DisableTokenAuthentication creates a rule that disables token authentication
+func DisableTokenAuthentication() *Rule {
+	return New(resources.EdgeRuleActionTypeDisableTokenAuthentication, "", "")
+}
+
+// EnableTokenAuthentication creates a rule that enables token authentication
+func EnableTokenAuthentication() *Rule {
+	return New(resources.EdgeRuleActionTypeEnableTokenAuthentication, "", "")
+}
+
+// IgnoreQueryString creates a rule that ignores the query string when caching
+func IgnoreQueryString() *Rule {
+	return New(resources.EdgeRuleActionTypeIgnoreQueryString, "", "")
+}
+
+// DisableOptimizer creates a rule that disables Bunny Optimizer
+func DisableOptimizer() *Rule {
+	return New(resources.EdgeRuleActionTypeDisableOptimizer, "", "")
+}
+
+// ForceCompression creates a rule that forces compression of the response
+func ForceCompression() *Rule {
+	return New(resources.EdgeRuleActionTypeForceCompression, "", "")
+}
+
+// SetStatusCode creates a rule that overrides the response status code
+func SetStatusCode(code int) *Rule {
+	return New(resources.EdgeRuleActionTypeSetStatusCode, strconv.Itoa(code), "")
+}
+
+// BypassPermaCache creates a rule that bypasses Perma-Cache
+func BypassPermaCache() *Rule {
+	return New(resources.EdgeRuleActionTypeBypassPermaCache, "", "")
+}
+
+// SetNetworkRateLimit creates a rule that limits the download speed in kB/s
+func SetNetworkRateLimit(kilobytesPerSecond int) *Rule {
+	return New(resources.EdgeRuleActionTypeSetNetworkRateLimit, strconv.Itoa(kilobytesPerSecond), "")
+}
+
+// SetConnectionLimit creates a rule that limits the number of connections per IP
+func SetConnectionLimit(connections int) *Rule {
+	return New(resources.EdgeRuleActionTypeSetConnectionLimit, strconv.Itoa(connections), "")
+}
+
+// SetRequestsPerSecondLimit creates a rule that limits the number of requests per second per IP
+func SetRequestsPerSecondLimit(requests int) *Rule {
+	return New(resources.EdgeRuleActionTypeSetRequestsPerSecondLimit, strconv.Itoa(requests), "")
+}
+
+// When adds triggers to the rule
+func (r *Rule) When(triggers ...resources.EdgeRuleTrigger) *Rule {
+	r.options.Triggers = append(r.options.Triggers, triggers...)
+	return r
+}
+
+// MatchAny runs the rule when any trigger matches, this is the default
+func (r *Rule) MatchAny() *Rule {
+	r.options.TriggerMatchingType = resources.TriggerMatchingTypeMatchAny
+	return r
+}
+
+// MatchAll runs the rule only when all triggers match
+func (r *Rule) MatchAll() *Rule {
+	r.options.TriggerMatchingType = resources.TriggerMatchingTypeMatchAll
+	return r
+}
+
+// MatchNone runs the rule only when no trigger matches
+func (r *Rule) MatchNone() *Rule {
+	r.options.TriggerMatchingType = resources.TriggerMatchingTypeMatchNone
+	return r
+}
+
+// Describe sets the description of the rule
+func (r *Rule) Describe(description string) *Rule {
+	r.options.Description = description
+	return r
+}
+
+// WithGuid sets the GUID of an existing rule so that it is updated instead of added
+func (r *Rule) WithGuid(guid string) *Rule {
+	r.options.Guid = guid
+	return r
+}
+
+// Disabled creates the rule in a disabled state
+func (r *Rule) Disabled() *Rule {
+	r.options.Enabled = false
+	return r
+}
+
+// Options returns the rule options without validating them
+func (r *Rule) Options() resources.AddOrUpdateEdgeRuleOptions {
+	options := r.options
+	options.Triggers = append([]resources.EdgeRuleTrigger(nil), r.options.Triggers...)
+	return options
+}
+
+// Build validates the rule and returns the options for AddOrUpdateEdgeRule
+func (r *Rule) Build() (resources.AddOrUpdateEdgeRuleOptions, error) {
+	options := r.Options()
+	if err := options.Validate(); err != nil {
+		return resources.AddOrUpdateEdgeRuleOptions{}, err
+	}
+	return options, nil
+}
+
+// EdgeRule returns the rule as it would be stored on a pull zone, for evaluating it locally with Evaluate
+func (r *Rule) EdgeRule() resources.EdgeRule {
+	options := r.Options()
+	return resources.EdgeRule{
+		Guid:                options.Guid,
+		ActionType:          options.ActionType,
+		ActionParameter1:    options.ActionParameter1,
+		ActionParameter2:    options.ActionParameter2,
+		Triggers:            options.Triggers,
+		TriggerMatchingType: options.TriggerMatchingType,
+		Description:         options.Description,
+		Enabled:             options.Enabled,
+	}
+}
+
+// formatSeconds formats a duration as whole seconds
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(d/time.Second), 10)
+}
+
+// formatOptionalInt formats a positive integer, or returns an empty string for zero
+func formatOptionalInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,321 @@
+package edgerule
+
+import (
+	"net/http"
+	"net/netip"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// Request is a synthetic request used to evaluate edge rules locally
+type Request struct {
+	// URL is the full request URL, including the scheme and hostname
+	URL string
+
+	// Method is the request method, defaults to GET
+	Method string
+
+	// Headers contains the request headers
+	Headers http.Header
+
+	// Cookies contains the request cookies by name
+	Cookies map[string]string
+
+	// Country is the two-letter country code of the client
+	Country string
+
+	// State is the state code of the client
+	State string
+
+	// RemoteIP is the IP address of the client
+	RemoteIP string
+
+	// StatusCode is the response status code, used by status code triggers
+	StatusCode int
+
+	// ResponseHeaders contains the response headers, used by response header triggers
+	ResponseHeaders http.Header
+
+	// Chance is the random roll of the request between 0 and 99, a RandomChance trigger
+	// with a percentage greater than Chance matches
+	Chance int
+
+	// OriginRetryAttemptCount is the number of attempts made to reach the origin
+	OriginRetryAttemptCount int
+
+	// OriginConnectionError indicates that the origin could not be reached
+	OriginConnectionError bool
+}
+
+// Match describes an edge rule that matched a request
+type Match struct {
+	// Index is the position of the rule in the evaluated list
+	Index int
+
+	// Rule is the matching edge rule
+	Rule resources.EdgeRule
+}
+
+// Result is the outcome of evaluating edge rules against a request.
+// Rules are applied in order, so later rules override earlier ones.
+type Result struct {
+	// Matches is the list of enabled rules that matched the request
+	Matches []Match
+
+	// ForceSSL indicates that a plain HTTP request is redirected to HTTPS
+	ForceSSL bool
+
+	// Blocked indicates that the request is blocked
+	Blocked bool
+
+	// RedirectURL is the URL the request is redirected to
+	RedirectURL string
+
+	// RedirectStatusCode is the status code of the redirect, empty when the API default is used
+	RedirectStatusCode string
+
+	// OriginURL is the origin URL the request is fetched from
+	OriginURL string
+
+	// StatusCode is the overridden response status code
+	StatusCode int
+
+	// CacheTime is the overridden edge cache time in seconds
+	CacheTime *int64
+
+	// BrowserCacheTime is the overridden browser cache time in seconds
+	BrowserCacheTime *int64
+
+	// RequestHeaders contains the headers set on the request sent to the origin
+	RequestHeaders http.Header
+
+	// ResponseHeaders contains the headers set on the response
+	ResponseHeaders http.Header
+}
+
+// Matched reports whether a rule with the given action matched the request
+func (r *Result) Matched(action resources.EdgeRuleActionType) bool {
+	for _, match := range r.Matches {
+		if match.Rule.ActionType == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate evaluates the edge rules in order against the request. Disabled rules are ignored.
+func Evaluate(rules []resources.EdgeRule, req Request) *Result {
+	result := &Result{
+		RequestHeaders:  http.Header{},
+		ResponseHeaders: http.Header{},
+	}
+
+	for i, rule := range rules {
+		if !rule.Enabled || !Matches(rule, req) {
+			continue
+		}
+		result.Matches = append(result.Matches, Match{Index: i, Rule: rule})
+		result.apply(rule, req)
+	}
+
+	return result
+}
+
+// EvaluatePullZone evaluates the edge rules of a pull zone against the request
+func EvaluatePullZone(zone *resources.PullZone, req Request) *Result {
+	return Evaluate(zone.EdgeRules, req)
+}
+
+// Matches reports whether the triggers of the rule match the request, regardless of whether it is enabled.
+// A rule without triggers never matches.
+func Matches(rule resources.EdgeRule, req Request) bool {
+	if len(rule.Triggers) == 0 {
+		return false
+	}
+
+	matched := 0
+	for _, trigger := range rule.Triggers {
+		if TriggerMatches(trigger, req) {
+			matched++
+		}
+	}
+
+	switch rule.TriggerMatchingType {
+	case resources.TriggerMatchingTypeMatchAll:
+		return matched == len(rule.Triggers)
+	case resources.TriggerMatchingTypeMatchNone:
+		return matched == 0
+	default:
+		return matched > 0
+	}
+}
+
+// TriggerMatches reports whether a single trigger matches the request
+func TriggerMatches(trigger resources.EdgeRuleTrigger, req Request) bool {
+	switch trigger.Type {
+	case resources.EdgeRuleTriggerTypeRandomChance:
+		percent, err := strconv.Atoi(trigger.Parameter1)
+		return err == nil && req.Chance < percent
+	case resources.EdgeRuleTriggerTypeOriginConnectionError:
+		return req.OriginConnectionError
+	}
+
+	values := triggerValues(trigger, req)
+	matched := 0
+	for _, pattern := range trigger.PatternMatches {
+		if matchAnyValue(trigger.Type, pattern, values) {
+			matched++
+		}
+	}
+
+	switch trigger.PatternMatchingType {
+	case resources.PatternMatchingTypeMatchAll:
+		return len(trigger.PatternMatches) > 0 && matched == len(trigger.PatternMatches)
+	case resources.PatternMatchingTypeMatchNone:
+		return matched == 0
+	default:
+		return matched > 0
+	}
+}
+
+// triggerValues returns the request values a trigger is matched against
+func triggerValues(trigger resources.EdgeRuleTrigger, req Request) []string {
+	u, _ := url.Parse(req.URL)
+	if u == nil {
+		u = &url.URL{}
+	}
+
+	switch trigger.Type {
+	case resources.EdgeRuleTriggerTypeUrl:
+		// The edge matches the full request URL including the query string, so a pattern
+		// such as "/images/*" never matches and must be written as "*/images/*"
+		full := u.Scheme + "://" + u.Host + u.EscapedPath()
+		if u.RawQuery != "" {
+			full += "?" + u.RawQuery
+		}
+		return []string{full}
+	case resources.EdgeRuleTriggerTypeRequestHeader:
+		return []string{req.Headers.Get(trigger.Parameter1)}
+	case resources.EdgeRuleTriggerTypeResponseHeader:
+		return []string{req.ResponseHeaders.Get(trigger.Parameter1)}
+	case resources.EdgeRuleTriggerTypeUrlExtension:
+		return []string{strings.TrimPrefix(path.Ext(u.Path), ".")}
+	case resources.EdgeRuleTriggerTypeCountryCode:
+		return []string{req.Country}
+	case resources.EdgeRuleTriggerTypeCountryStateCode:
+		return []string{req.State}
+	case resources.EdgeRuleTriggerTypeRemoteIP:
+		return []string{req.RemoteIP}
+	case resources.EdgeRuleTriggerTypeUrlQueryString:
+		return []string{u.Query().Get(trigger.Parameter1)}
+	case resources.EdgeRuleTriggerTypeStatusCode:
+		return []string{strconv.Itoa(req.StatusCode)}
+	case resources.EdgeRuleTriggerTypeRequestMethod:
+		if req.Method == "" {
+			return []string{http.MethodGet}
+		}
+		return []string{req.Method}
+	case resources.EdgeRuleTriggerTypeCookieValue:
+		return []string{req.Cookies[trigger.Parameter1]}
+	case resources.EdgeRuleTriggerTypeOriginRetryAttemptCount:
+		return []string{strconv.Itoa(req.OriginRetryAttemptCount)}
+	}
+	return nil
+}
+
+// matchAnyValue reports whether the pattern matches any of the values
+func matchAnyValue(triggerType resources.EdgeRuleTriggerType, pattern string, values []string) bool {
+	for _, value := range values {
+		if triggerType == resources.EdgeRuleTriggerTypeRemoteIP && matchIP(pattern, value) {
+			return true
+		}
+		if MatchPattern(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchIP reports whether the IP address is inside the CIDR range or equal to the address in the pattern
+func matchIP(pattern, value string) bool {
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return false
+	}
+	if prefix, err := netip.ParsePrefix(pattern); err == nil {
+		return prefix.Contains(addr.Unmap())
+	}
+	if other, err := netip.ParseAddr(pattern); err == nil {
+		return other.Unmap() == addr.Unmap()
+	}
+	return false
+}
+
+// MatchPattern reports whether the value matches a wildcard pattern.
+// The * wildcard matches any sequence of characters, and matching is case-insensitive.
+func MatchPattern(pattern, value string) bool {
+	pattern = strings.ToLower(pattern)
+	value = strings.ToLower(value)
+
+	// Iterative wildcard matching with backtracking to the last star
+	p, v := 0, 0
+	star, mark := -1, 0
+	for v < len(value) {
+		switch {
+		case p < len(pattern) && pattern[p] != '*' && pattern[p] == value[v]:
+			p++
+			v++
+		case p < len(pattern) && pattern[p] == '*':
+			star = p
+			mark = v
+			p++
+		case star >= 0:
+			p = star + 1
+			mark++
+			v = mark
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// apply records the effect of a matching rule
+func (r *Result) apply(rule resources.EdgeRule, req Request) {
+	switch rule.ActionType {
+	case resources.EdgeRuleActionTypeForceSSL:
+		if u, err := url.Parse(req.URL); err == nil && u.Scheme == "http" {
+			r.ForceSSL = true
+		}
+	case resources.EdgeRuleActionTypeRedirect:
+		r.RedirectURL = rule.ActionParameter1
+		r.RedirectStatusCode = rule.ActionParameter2
+	case resources.EdgeRuleActionTypeOriginUrl:
+		r.OriginURL = rule.ActionParameter1
+	case resources.EdgeRuleActionTypeBlockRequest:
+		r.Blocked = true
+	case resources.EdgeRuleActionTypeSetResponseHeader:
+		r.ResponseHeaders.Set(rule.ActionParameter1, rule.ActionParameter2)
+	case resources.EdgeRuleActionTypeSetRequestHeader:
+		r.RequestHeaders.Set(rule.ActionParameter1, rule.ActionParameter2)
+	case resources.EdgeRuleActionTypeSetStatusCode:
+		if code, err := strconv.Atoi(rule.ActionParameter1); err == nil {
+			r.StatusCode = code
+		}
+	case resources.EdgeRuleActionTypeOverrideCacheTime:
+		if seconds, err := strconv.ParseInt(rule.ActionParameter1, 10, 64); err == nil {
+			r.CacheTime = &seconds
+		}
+	case resources.EdgeRuleActionTypeOverrideCacheTimePublic, resources.EdgeRuleActionTypeOverrideBrowserCacheTime:
+		if seconds, err := strconv.ParseInt(rule.ActionParameter1, 10, 64); err == nil {
+			r.BrowserCacheTime = &seconds
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package edgerule
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// Trigger creates a trigger of the given type that matches any of the patterns
+func Trigger(triggerType resources.EdgeRuleTriggerType, parameter1 string, patterns ...string) resources.EdgeRuleTrigger {
+	return resources.EdgeRuleTrigger{
+		Type:                triggerType,
+		PatternMatches:      patterns,
+		PatternMatchingType: resources.PatternMatchingTypeMatchAny,
+		Parameter1:          parameter1,
+	}
+}
+
+// URLMatches matches the request URL against wildcard patterns such as "*/images/*"
+func URLMatches(patterns ...string) resources.EdgeRuleTrigger {
+	return Trigger(resources.EdgeRuleTriggerTypeUrl, "", patterns...)
+}
+
+// RequestHeader matches the value of a request header
+func RequestHeader(name string, patterns ...string) resources.EdgeRuleTrigger {
+	return Trigger(resources.EdgeRuleTriggerTypeRequestHeader, name, patterns...)
+}
+
+// ResponseHeader matches the value of a response header
+func ResponseHeader(name string, patterns ...string) resources.EdgeRuleTrigger {
+	return Trigger(resources.EdgeRuleTriggerTypeResponseHeader, name, patterns...)
+}
+
+// Extension matches the file extension of the request URL, with or without a leading dot
+func Extension(extensions ...string) resources.EdgeRuleTrigger {
+	patterns := make([]string, 0, len(extensions))
+	for _, extension := range extensions {
+		patterns = append(patterns, strings.TrimPrefix(extension, "."))
+	}
+	return Trigger(resources.EdgeRuleTriggerTypeUrlExtension, "", patterns...)
+}
+
+// Country matches the two-letter country code of the client
+func Country(codes ...string) resources.EdgeRuleTrigger {
+	return Trigger(resources.EdgeRuleTriggerTypeCountryCode, "", codes...)
+}
+
+// CountryState matches the state code of the client
+func CountryState(codes ...string) resources.EdgeRuleTrigger {
+	return Trigger(resources.EdgeRuleTriggerTypeCountryStateCode, "", codes...)
+}
+
+// RemoteIP matches the client IP address against addresses or CIDR ranges
+func RemoteIP(addresses ...string) resources.EdgeRuleTrigger {
+	return Trigger(resources.EdgeRuleTriggerTypeRemoteIP, "", addresses...)
+}
+
+// QueryString matches the value of a query string parameter
+func QueryString(name string, patterns ...string) resources.EdgeRuleTrigger {
+	return Trigger(resources.EdgeRuleTriggerTypeUrlQueryString, name, patterns...)
+}
+
+// Cookie matches the value of a cookie
+func Cookie(name string, patterns ...string) resources.EdgeRuleTrigger {
+	return Trigger(resources.EdgeRuleTriggerTypeCookieValue, name, patterns...)
+}
+
+// StatusCode matches the response status code
+func StatusCode(codes ...int) resources.EdgeRuleTrigger {
+	patterns := make([]string, 0, len(codes))
+	for _, code := range codes {
+		patterns = append(patterns, strconv.Itoa(code))
+	}
+	return Trigger(resources.EdgeRuleTriggerTypeStatusCode, "", patterns...)
+}
+
+// RequestMethod matches the request method
+func RequestMethod(methods ...string) resources.EdgeRuleTrigger {
+	return Trigger(resources.EdgeRuleTriggerTypeRequestMethod, "", methods...)
+}
+
+// RandomChance matches the given percentage of requests
+func RandomChance(percent int) resources.EdgeRuleTrigger {
+	return Trigger(resources.EdgeRuleTriggerTypeRandomChance, strconv.Itoa(percent))
+}
+
+// OriginRetryAttemptCount matches the number of attempts made to reach the origin
+func OriginRetryAttemptCount(counts ...int) resources.EdgeRuleTrigger {
+	patterns := make([]string, 0, len(counts))
+	for _, count := range counts {
+		patterns = append(patterns, strconv.Itoa(count))
+	}
+	return Trigger(resources.EdgeRuleTriggerTypeOriginRetryAttemptCount, "", patterns...)
+}
+
+// OriginConnectionError matches requests where the origin could not be reached
+func OriginConnectionError() resources.EdgeRuleTrigger {
+	return Trigger(resources.EdgeRuleTriggerTypeOriginConnectionError, "")
+}
+
+// All requires every pattern of the trigger to match
+func All(trigger resources.EdgeRuleTrigger) resources.EdgeRuleTrigger {
+	trigger.PatternMatchingType = resources.PatternMatchingTypeMatchAll
+	return trigger
+}
+
+// Not inverts the trigger so that it matches when none of its patterns match
+func Not(trigger resources.EdgeRuleTrigger) resources.EdgeRuleTrigger {
+	trigger.PatternMatchingType = resources.PatternMatchingTypeMatchNone
+	return trigger
+}
@@ -183,10 +183,10 @@ func addDNSRecords(ctx context.Context, client *bunnynet.Client, zoneId int64) {
 	// Update the CNAME record
 	updateRecordOptions := resources.UpdateDNSRecordOptions{
 		Id:    cnameRecord.Id,
-		Type:  resources.DNSRecordTypeCNAME,
-		Name:  "blog",
-		Value: "@",
-		Ttl:   7200,
+		Type:  common.Ptr(resources.DNSRecordTypeCNAME),
+		Name:  common.Ptr("blog"),
+		Value: common.Ptr("@"),
+		Ttl:   common.Ptr(int32(7200)),
 	}
 
 	err = client.DNSZone.UpdateRecord(ctx, zoneId, cnameRecord.Id, updateRecordOptions)
@@ -195,7 +195,7 @@ func addDNSRecords(ctx context.Context, client *bunnynet.Client, zoneId int64) {
 		return
 	}
 	fmt.Printf("Updated CNAME record (ID=%d) to Name=%s, TTL=%d\n",
-		cnameRecord.Id, updateRecordOptions.Name, updateRecordOptions.Ttl)
+		cnameRecord.Id, *updateRecordOptions.Name, *updateRecordOptions.Ttl)
 
 	// Delete the MX record
 	err = client.DNSZone.DeleteRecord(ctx, zoneId, mxRecord.Id)
@@ -209,13 +209,13 @@ func addDNSRecords(ctx context.Context, client *bunnynet.Client, zoneId int64) {
 func updateDNSZone(ctx context.Context, client *bunnynet.Client, zoneId int64) {
 	// Update the DNS zone settings
 	options := resources.UpdateDNSZoneOptions{
-		CustomNameserversEnabled:      true,
-		Nameserver1:                   "ns1.example.com",
-		Nameserver2:                   "ns2.example.com",
-		SoaEmail:                      "admin@example.com",
-		LoggingEnabled:                true,
-		LogAnonymizationType:          resources.LogAnonymizationTypeOneDigit,
-		LoggingIPAnonymizationEnabled: true,
+		CustomNameserversEnabled:      common.Ptr(true),
+		Nameserver1:                   common.Ptr("ns1.example.com"),
+		Nameserver2:                   common.Ptr("ns2.example.com"),
+		SoaEmail:                      common.Ptr("admin@example.com"),
+		LoggingEnabled:                common.Ptr(true),
+		LogAnonymizationType:          common.Ptr(resources.LogAnonymizationTypeOneDigit),
+		LoggingIPAnonymizationEnabled: common.Ptr(true),
 	}
 
 	updatedZone, err := client.DNSZone.Update(ctx, zoneId, options)
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/iac"
+)
+
+func main() {
+	configPath := flag.String("config", "infra.yaml", "path to the YAML configuration")
+	apply := flag.Bool("apply", false, "apply the plan instead of only printing it")
+	prune := flag.Bool("prune", false, "delete zones that are not declared in the configuration")
+	flag.Parse()
+
+	// Get API key from environment variable
+	apiKey := os.Getenv("BUNNYNET_API_KEY")
+	if apiKey == "" {
+		log.Fatal("BUNNYNET_API_KEY environment variable is not set")
+	}
+
+	// Create a new client
+	client := bunnynet.NewClient(
+		apiKey,
+		bunnynet.WithTimeout(30*time.Second),
+	)
+
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// Load the desired state
+	config, err := iac.LoadFile(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Compute the plan against the live state
+	plan, err := iac.BuildPlan(ctx, client, config, &iac.PlanOptions{Prune: *prune})
+	if err != nil {
+		log.Fatalf("Failed to build plan: %v", err)
+	}
+
+	// Print the dry-run output
+	if err := plan.Render(os.Stdout); err != nil {
+		log.Fatalf("Failed to render plan: %v", err)
+	}
+
+	if !*apply || !plan.HasChanges() {
+		return
+	}
+
+	// Apply the changes in dependency order
+	result, err := iac.Apply(ctx, client, plan)
+	if err != nil {
+		log.Fatalf("Applied %d changes before failing: %v", len(result.Applied), err)
+	}
+
+	fmt.Printf("\nApplied %d changes\n", len(result.Applied))
+}
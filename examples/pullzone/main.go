@@ -9,6 +9,7 @@ import (
 
 	"github.com/venom90/bunnynet-go"
 	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/edgerule"
 	"github.com/venom90/bunnynet-go/resources"
 )
 
@@ -88,7 +89,7 @@ func createPullZone(ctx context.Context, client *bunnynet.Client) *resources.Pul
 	options := resources.AddPullZoneOptions{
 		Name:              "example-pull-zone",
 		OriginUrl:         "https://example.com",
-		Type:              0, // Premium
+		Type:              resources.PullZoneTypePremium,
 		EnableGeoZoneUS:   true,
 		EnableGeoZoneEU:   true,
 		EnableGeoZoneASIA: true,
@@ -165,22 +166,17 @@ func addEdgeRule(ctx context.Context, client *bunnynet.Client, id int64) {
 	fmt.Printf("Adding Edge Rule to Pull Zone ID: %d\n", id)
 
 	// Create Edge Rule options
-	options := resources.AddOrUpdateEdgeRuleOptions{
-		ActionType: 0, // ForceSSL
-		Triggers: []resources.EdgeRuleTrigger{
-			{
-				Type:                0, // URL
-				PatternMatches:      []string{"/*"},
-				PatternMatchingType: 0, // MatchAny
-				TriggerMatchingType: 0, // MatchAny
-			},
-		},
-		Description: "Force SSL for all URLs",
-		Enabled:     true,
+	options, err := edgerule.ForceSSL().
+		When(edgerule.URLMatches("/*")).
+		Describe("Force SSL for all URLs").
+		Build()
+	if err != nil {
+		log.Printf("Invalid Edge Rule: %v", err)
+		return
 	}
 
 	// Add Edge Rule
-	err := client.PullZone.AddOrUpdateEdgeRule(ctx, id, options)
+	err = client.PullZone.AddOrUpdateEdgeRule(ctx, id, options)
 	if err != nil {
 		log.Printf("Failed to add Edge Rule: %v", err)
 		return
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/zonefile"
+)
+
+func main() {
+	zoneId := flag.Int64("zone", 0, "ID of the DNS zone")
+	importPath := flag.String("import", "", "zone file to add to the DNS zone instead of printing it")
+	flag.Parse()
+
+	// Get API key from environment variable
+	apiKey := os.Getenv("BUNNYNET_API_KEY")
+	if apiKey == "" {
+		log.Fatal("BUNNYNET_API_KEY environment variable is not set")
+	}
+
+	// Create a new client
+	client := bunnynet.NewClient(
+		apiKey,
+		bunnynet.WithTimeout(30*time.Second),
+	)
+
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	zone, err := client.DNSZone.Get(ctx, *zoneId)
+	if err != nil {
+		log.Fatalf("Failed to get DNS zone: %v", err)
+	}
+
+	if *importPath == "" {
+		// Print the zone as a zone file
+		data, skipped := zonefile.Render(zone)
+		fmt.Print(string(data))
+		for _, record := range skipped {
+			fmt.Fprintf(os.Stderr, "Skipped Bunny-only record %q (type %d)\n", record.Name, record.Type)
+		}
+		return
+	}
+
+	// Parse the zone file and add its records
+	file, err := os.Open(*importPath)
+	if err != nil {
+		log.Fatalf("Failed to open zone file: %v", err)
+	}
+	defer file.Close()
+
+	records, err := zonefile.Parse(file, zone.Domain)
+	if err != nil {
+		log.Fatalf("Failed to parse zone file: %v", err)
+	}
+
+	for _, record := range records {
+		if _, err := client.DNSZone.AddRecord(ctx, zone.Id, record); err != nil {
+			log.Fatalf("Failed to add record %q: %v", record.Name, err)
+		}
+	}
+
+	fmt.Printf("Added %d records to %s\n", len(records), zone.Domain)
+}
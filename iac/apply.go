@@ -0,0 +1,73 @@
+package iac
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/venom90/bunnynet-go"
+)
+
+// applyState tracks the IDs of zones while a plan is applied, including zones created by earlier changes
+type applyState struct {
+	client      *bunnynet.Client
+	pullZoneIDs map[string]int64
+	dnsZoneIDs  map[string]int64
+}
+
+// pullZoneID returns the ID of the pull zone with the given lower-cased name
+func (s *applyState) pullZoneID(key string) (int64, error) {
+	id, ok := s.pullZoneIDs[key]
+	if !ok {
+		return 0, fmt.Errorf("pull zone %q does not exist", key)
+	}
+	return id, nil
+}
+
+// dnsZoneID returns the ID of the DNS zone with the given normalized domain
+func (s *applyState) dnsZoneID(key string) (int64, error) {
+	id, ok := s.dnsZoneIDs[key]
+	if !ok {
+		return 0, fmt.Errorf("DNS zone %q does not exist", key)
+	}
+	return id, nil
+}
+
+// ApplyResult represents the outcome of applying a plan
+type ApplyResult struct {
+	// Applied is the list of changes that were applied successfully
+	Applied []Change
+
+	// Failed is the change that failed, nil if all changes were applied
+	Failed *Change
+}
+
+// Apply applies the changes of a plan in order and stops at the first failure.
+// The result is returned together with the error so that callers can report partial progress.
+func Apply(ctx context.Context, client *bunnynet.Client, plan *Plan) (*ApplyResult, error) {
+	state := &applyState{
+		client:      client,
+		pullZoneIDs: make(map[string]int64, len(plan.pullZoneIDs)),
+		dnsZoneIDs:  make(map[string]int64, len(plan.dnsZoneIDs)),
+	}
+	for k, v := range plan.pullZoneIDs {
+		state.pullZoneIDs[k] = v
+	}
+	for k, v := range plan.dnsZoneIDs {
+		state.dnsZoneIDs[k] = v
+	}
+
+	result := &ApplyResult{}
+	for i := range plan.Changes {
+		change := plan.Changes[i]
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if err := change.apply(ctx, state); err != nil {
+			result.Failed = &change
+			return result, fmt.Errorf("failed to %s %s: %w", change.Action, change.Address, err)
+		}
+		result.Applied = append(result.Applied, change)
+	}
+
+	return result, nil
+}
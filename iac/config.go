@@ -0,0 +1,293 @@
+// Package iac provides declarative plan/apply support for Bunny.net pull zones and DNS zones
+package iac
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/resources"
+	"gopkg.in/yaml.v3"
+)
+
+// Config describes the desired state of pull zones and DNS zones
+type Config struct {
+	// PullZones is the list of pull zones managed by this configuration
+	PullZones []PullZoneConfig `yaml:"pullZones"`
+
+	// DNSZones is the list of DNS zones managed by this configuration
+	DNSZones []DNSZoneConfig `yaml:"dnsZones"`
+}
+
+// PullZoneConfig describes the desired state of a single pull zone.
+// List fields left out of the configuration are not managed; an empty list removes all entries.
+type PullZoneConfig struct {
+	// Name is the name of the pull zone and identifies it in the live state
+	Name string `yaml:"name"`
+
+	// OriginUrl is the origin URL of the pull zone
+	OriginUrl string `yaml:"originUrl"`
+
+	// Type is the type of pull zone, such as "Premium" or "Volume". When left out the type of an
+	// existing zone is not managed and new zones are created as Premium.
+	Type *resources.PullZoneType `yaml:"type,omitempty"`
+
+	// Hostnames is the list of custom hostnames linked to the pull zone
+	Hostnames []string `yaml:"hostnames"`
+
+	// AllowedReferrers is the list of referrer hostnames that are allowed to access the pull zone
+	AllowedReferrers []string `yaml:"allowedReferrers"`
+
+	// BlockedReferrers is the list of referrer hostnames that are blocked from accessing the pull zone
+	BlockedReferrers []string `yaml:"blockedReferrers"`
+
+	// BlockedIps is the list of IPs that are blocked from accessing the pull zone
+	BlockedIps []string `yaml:"blockedIps"`
+
+	// EdgeRules is the list of edge rules on the pull zone
+	EdgeRules []EdgeRuleConfig `yaml:"edgeRules"`
+
+	// Cache contains the cache settings of the pull zone
+	Cache CacheConfig `yaml:"cache"`
+}
+
+// CacheConfig contains the cache settings of a pull zone.
+// Field names match the PullZone fields they control; unset fields are left unchanged.
+type CacheConfig struct {
+	// CacheControlMaxAgeOverride is the override cache time for the pull zone
+	CacheControlMaxAgeOverride *int64 `yaml:"cacheControlMaxAgeOverride"`
+
+	// CacheControlPublicMaxAgeOverride is the override cache time for the pull zone for the end client
+	CacheControlPublicMaxAgeOverride *int64 `yaml:"cacheControlPublicMaxAgeOverride"`
+
+	// IgnoreQueryStrings determines if query strings are ignored when serving cached objects
+	IgnoreQueryStrings *bool `yaml:"ignoreQueryStrings"`
+
+	// EnableQueryStringOrdering determines if the query string ordering property is enabled
+	EnableQueryStringOrdering *bool `yaml:"enableQueryStringOrdering"`
+
+	// QueryStringVaryParameters contains the list of vary parameters for vary cache by query string
+	QueryStringVaryParameters []string `yaml:"queryStringVaryParameters"`
+
+	// EnableSmartCache determines if smart caching is enabled
+	EnableSmartCache *bool `yaml:"enableSmartCache"`
+
+	// EnableCacheSlice determines if the cache slice (Optimize for video) feature is enabled
+	EnableCacheSlice *bool `yaml:"enableCacheSlice"`
+
+	// CacheErrorResponses determines if error responses are cached
+	CacheErrorResponses *bool `yaml:"cacheErrorResponses"`
+
+	// UseStaleWhileUpdating determines if stale cache is served while the cache is updating
+	UseStaleWhileUpdating *bool `yaml:"useStaleWhileUpdating"`
+
+	// UseStaleWhileOffline determines if stale cache is served while the origin is offline
+	UseStaleWhileOffline *bool `yaml:"useStaleWhileOffline"`
+
+	// EnableWebPVary determines if the WebP Vary feature is enabled
+	EnableWebPVary *bool `yaml:"enableWebPVary"`
+
+	// EnableAvifVary determines if the AVIF Vary feature is enabled
+	EnableAvifVary *bool `yaml:"enableAvifVary"`
+
+	// EnableCountryCodeVary determines if the Country Code Vary feature is enabled
+	EnableCountryCodeVary *bool `yaml:"enableCountryCodeVary"`
+
+	// EnableMobileVary determines if the Mobile Vary feature is enabled
+	EnableMobileVary *bool `yaml:"enableMobileVary"`
+
+	// EnableHostnameVary determines if the Hostname Vary feature is enabled
+	EnableHostnameVary *bool `yaml:"enableHostnameVary"`
+
+	// EnableCookieVary determines if the Cookie Vary feature is enabled
+	EnableCookieVary *bool `yaml:"enableCookieVary"`
+
+	// CookieVaryParameters contains the list of cookie names used for vary cache by cookie
+	CookieVaryParameters []string `yaml:"cookieVaryParameters"`
+}
+
+// EdgeRuleConfig describes the desired state of an edge rule.
+// Rules are matched against the live state by Guid when set, otherwise by Description.
+type EdgeRuleConfig struct {
+	// Guid is the unique GUID of an existing edge rule
+	Guid string `yaml:"guid"`
+
+	// Description is the description of the edge rule
+	Description string `yaml:"description"`
+
+	// ActionType is the type of action that the edge rule performs
+	ActionType resources.EdgeRuleActionType `yaml:"actionType"`
+
+	// ActionParameter1 is the action parameter 1
+	ActionParameter1 string `yaml:"actionParameter1"`
+
+	// ActionParameter2 is the action parameter 2
+	ActionParameter2 string `yaml:"actionParameter2"`
+
+	// Triggers is the list of triggers for the edge rule
+	Triggers []EdgeRuleTriggerConfig `yaml:"triggers"`
+
+	// TriggerMatchingType defines how the triggers of the edge rule are combined
+	TriggerMatchingType resources.TriggerMatchingType `yaml:"triggerMatchingType"`
+
+	// Enabled determines if the edge rule is enabled, defaults to true
+	Enabled *bool `yaml:"enabled"`
+}
+
+// EdgeRuleTriggerConfig describes a trigger of an edge rule
+type EdgeRuleTriggerConfig struct {
+	// Type is the type of trigger
+	Type resources.EdgeRuleTriggerType `yaml:"type"`
+
+	// PatternMatches is the list of pattern matches that will trigger the edge rule
+	PatternMatches []string `yaml:"patternMatches"`
+
+	// PatternMatchingType defines how patterns should be matched
+	PatternMatchingType resources.PatternMatchingType `yaml:"patternMatchingType"`
+
+	// Parameter1 is the trigger parameter 1
+	Parameter1 string `yaml:"parameter1"`
+
+	// TriggerMatchingType defines how triggers should be matched
+	TriggerMatchingType resources.TriggerMatchingType `yaml:"triggerMatchingType"`
+}
+
+// DNSZoneConfig describes the desired state of a DNS zone.
+// When Records is left out the records of the zone are not managed.
+type DNSZoneConfig struct {
+	// Domain is the domain of the DNS zone and identifies it in the live state
+	Domain string `yaml:"domain"`
+
+	// Records is the list of DNS records in the zone
+	Records []DNSRecordConfig `yaml:"records"`
+}
+
+// DNSRecordConfig describes the desired state of a DNS record.
+// Records are matched against the live state by Name, Type and Value.
+type DNSRecordConfig struct {
+	// Name is the name of the record relative to the zone, "@" or empty for the apex
+	Name string `yaml:"name"`
+
+	// Type is the type of the DNS record
+	Type resources.DNSRecordType `yaml:"type"`
+
+	// Value is the value of the DNS record
+	Value string `yaml:"value"`
+
+	// Ttl is the time to live of the DNS record
+	Ttl int32 `yaml:"ttl"`
+
+	// Priority is the priority of the DNS record
+	Priority int32 `yaml:"priority"`
+
+	// Weight is the weight of the DNS record
+	Weight int32 `yaml:"weight"`
+
+	// Port is the port of the DNS record
+	Port int32 `yaml:"port"`
+
+	// Flags is the flags of the DNS record
+	Flags int `yaml:"flags"`
+
+	// Tag is the tag of the DNS record
+	Tag string `yaml:"tag"`
+
+	// PullZone is the name of the pull zone linked by a PullZone record.
+	// The pull zone may be declared in the same configuration.
+	PullZone string `yaml:"pullZone"`
+
+	// Disabled indicates whether the DNS record is disabled
+	Disabled bool `yaml:"disabled"`
+
+	// Comment is the comment of the DNS record
+	Comment string `yaml:"comment"`
+}
+
+// LoadFile reads and parses a YAML configuration file
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(bytes.NewReader(data))
+}
+
+// Parse parses a YAML configuration and validates it
+func Parse(r io.Reader) (*Config, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	var config Config
+	if err := decoder.Decode(&config); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// Validate checks the configuration for missing and duplicate identifiers
+func (c *Config) Validate() error {
+	pullZones := make(map[string]bool)
+	for i, zone := range c.PullZones {
+		if zone.Name == "" {
+			return fmt.Errorf("pullZones[%d]: name is required", i)
+		}
+		if zone.OriginUrl == "" {
+			return fmt.Errorf("pull zone %q: originUrl is required", zone.Name)
+		}
+		key := strings.ToLower(zone.Name)
+		if pullZones[key] {
+			return fmt.Errorf("pull zone %q is declared more than once", zone.Name)
+		}
+		pullZones[key] = true
+
+		rules := make(map[string]bool)
+		for j, rule := range zone.EdgeRules {
+			id := edgeRuleKey(rule)
+			if id == "" {
+				return fmt.Errorf("pull zone %q: edgeRules[%d] needs a guid or description", zone.Name, j)
+			}
+			if rules[id] {
+				return fmt.Errorf("pull zone %q: edge rule %q is declared more than once", zone.Name, id)
+			}
+			rules[id] = true
+
+			if err := edgeRuleOptions(rule).Validate(); err != nil {
+				return fmt.Errorf("pull zone %q: edge rule %q: %w", zone.Name, id, err)
+			}
+		}
+	}
+
+	dnsZones := make(map[string]bool)
+	for i, zone := range c.DNSZones {
+		if zone.Domain == "" {
+			return fmt.Errorf("dnsZones[%d]: domain is required", i)
+		}
+		key := strings.ToLower(zone.Domain)
+		if dnsZones[key] {
+			return fmt.Errorf("DNS zone %q is declared more than once", zone.Domain)
+		}
+		dnsZones[key] = true
+
+		records := make(map[string]bool)
+		for _, record := range zone.Records {
+			if record.Type == resources.DNSRecordTypePullZone && record.PullZone == "" {
+				return fmt.Errorf("DNS zone %q: PullZone record %q needs a pullZone", zone.Domain, record.Name)
+			}
+			id := dnsRecordConfigKey(record)
+			if records[id] {
+				return fmt.Errorf("DNS zone %q: record %s is declared more than once", zone.Domain, id)
+			}
+			records[id] = true
+		}
+	}
+
+	return nil
+}
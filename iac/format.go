@@ -0,0 +1,68 @@
+package iac
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// actionSymbols maps actions to the prefixes used when rendering a plan
+var actionSymbols = map[Action]string{
+	ActionCreate: "+",
+	ActionUpdate: "~",
+	ActionDelete: "-",
+}
+
+// Render writes a human-readable dry-run report of the plan
+func (p *Plan) Render(w io.Writer) error {
+	if !p.HasChanges() {
+		_, err := fmt.Fprintln(w, "No changes. The live state matches the configuration.")
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString("Planned changes:\n\n")
+
+	for _, change := range p.Changes {
+		fmt.Fprintf(&b, "  %s %s\n", actionSymbols[change.Action], change.Address)
+		for _, field := range change.Fields {
+			switch change.Action {
+			case ActionCreate:
+				fmt.Fprintf(&b, "      %s = %s\n", field.Field, formatValue(field.New))
+			default:
+				fmt.Fprintf(&b, "      %s: %s => %s\n", field.Field, formatValue(field.Old), formatValue(field.New))
+			}
+		}
+	}
+
+	add, change, destroy := p.Summary()
+	fmt.Fprintf(&b, "\nPlan: %d to add, %d to change, %d to destroy.\n", add, change, destroy)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// String returns the rendered plan
+func (p *Plan) String() string {
+	var b strings.Builder
+	_ = p.Render(&b)
+	return b.String()
+}
+
+// formatValue formats a field value for display
+func formatValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return "(none)"
+	case string:
+		return fmt.Sprintf("%q", value)
+	case []string:
+		quoted := make([]string, len(value))
+		for i, s := range value {
+			quoted[i] = fmt.Sprintf("%q", s)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	default:
+		return fmt.Sprintf("%+v", value)
+	}
+}
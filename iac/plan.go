@@ -0,0 +1,855 @@
+package iac
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// Action is the kind of change planned for a resource
+type Action string
+
+const (
+	// ActionCreate creates a resource that does not exist yet
+	ActionCreate Action = "create"
+	// ActionUpdate updates an existing resource in place
+	ActionUpdate Action = "update"
+	// ActionDelete deletes an existing resource
+	ActionDelete Action = "delete"
+)
+
+// Phases determine the order in which changes are applied.
+// Containers are created before their contents and deleted after them,
+// and child deletions run before child creations so that values can move between zones.
+const (
+	phasePullZone = iota
+	phasePullZoneChildDelete
+	phasePullZoneChild
+	phaseDNSZone
+	phaseDNSRecordDelete
+	phaseDNSRecord
+	phaseDNSZoneDelete
+	phasePullZoneDelete
+)
+
+// FieldChange describes the change of a single field
+type FieldChange struct {
+	// Field is the name of the field
+	Field string
+
+	// Old is the current value, nil when the resource is created
+	Old interface{}
+
+	// New is the desired value, nil when the resource is deleted
+	New interface{}
+}
+
+// Change is a single planned change of a resource
+type Change struct {
+	// Action is the kind of change
+	Action Action
+
+	// Address identifies the resource, e.g. pullzone.site.hostname[cdn.example.com]
+	Address string
+
+	// Fields lists the field level changes
+	Fields []FieldChange
+
+	phase int
+	apply func(ctx context.Context, state *applyState) error
+}
+
+// Plan is an ordered list of changes that bring the live state in line with a configuration
+type Plan struct {
+	// Changes is the list of changes in the order they are applied
+	Changes []Change
+
+	pullZoneIDs map[string]int64
+	dnsZoneIDs  map[string]int64
+}
+
+// PlanOptions represents the options for building a plan
+type PlanOptions struct {
+	// Prune deletes live pull zones and DNS zones that are not declared in the configuration
+	Prune bool
+}
+
+// HasChanges returns true if the plan contains at least one change
+func (p *Plan) HasChanges() bool {
+	return len(p.Changes) > 0
+}
+
+// Summary returns the number of resources to add, change and destroy
+func (p *Plan) Summary() (add, change, destroy int) {
+	for _, c := range p.Changes {
+		switch c.Action {
+		case ActionCreate:
+			add++
+		case ActionUpdate:
+			change++
+		case ActionDelete:
+			destroy++
+		}
+	}
+	return add, change, destroy
+}
+
+// planner accumulates the changes of a plan
+type planner struct {
+	config  *Config
+	options *PlanOptions
+	plan    *Plan
+}
+
+// BuildPlan computes the changes needed to bring the live state in line with the configuration
+func BuildPlan(ctx context.Context, client *bunnynet.Client, config *Config, options *PlanOptions) (*Plan, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if options == nil {
+		options = &PlanOptions{}
+	}
+
+	livePullZones, err := client.PullZone.ListAll(ctx, 0, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	liveDNSZones, err := client.DNSZone.ListAll(ctx, 0, "")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &planner{
+		config:  config,
+		options: options,
+		plan: &Plan{
+			pullZoneIDs: make(map[string]int64),
+			dnsZoneIDs:  make(map[string]int64),
+		},
+	}
+
+	for _, zone := range livePullZones {
+		p.plan.pullZoneIDs[strings.ToLower(zone.Name)] = zone.Id
+	}
+
+	p.planPullZones(livePullZones)
+
+	if err := p.planDNSZones(ctx, client, liveDNSZones); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(p.plan.Changes, func(i, j int) bool {
+		return p.plan.Changes[i].phase < p.plan.Changes[j].phase
+	})
+
+	return p.plan, nil
+}
+
+// add appends a change to the plan
+func (p *planner) add(phase int, action Action, address string, fields []FieldChange, apply func(ctx context.Context, state *applyState) error) {
+	p.plan.Changes = append(p.plan.Changes, Change{
+		Action:  action,
+		Address: address,
+		Fields:  fields,
+		phase:   phase,
+		apply:   apply,
+	})
+}
+
+// planPullZones plans the changes of all pull zones
+func (p *planner) planPullZones(live []resources.PullZone) {
+	declared := make(map[string]bool)
+
+	for _, desired := range p.config.PullZones {
+		key := strings.ToLower(desired.Name)
+		declared[key] = true
+		address := "pullzone." + desired.Name
+
+		current := findPullZone(live, desired.Name)
+		if current == nil {
+			fields := []FieldChange{{Field: "OriginUrl", New: desired.OriginUrl}}
+			options := resources.AddPullZoneOptions{Name: desired.Name, OriginUrl: desired.OriginUrl}
+			if desired.Type != nil {
+				fields = append(fields, FieldChange{Field: "Type", New: *desired.Type})
+				options.Type = *desired.Type
+			}
+			fields = append(fields, settingChanges(&desired.Cache, nil)...)
+
+			p.add(phasePullZone, ActionCreate, address, fields, func(ctx context.Context, state *applyState) error {
+				zone, err := state.client.PullZone.Add(ctx, options)
+				if err != nil {
+					return err
+				}
+				state.pullZoneIDs[key] = zone.Id
+
+				if !hasSettings(&desired.Cache) {
+					return nil
+				}
+				modified := *zone
+				applySettings(&desired.Cache, &modified)
+				_, err = state.client.PullZone.Patch(ctx, zone.Id, zone, &modified)
+				return err
+			})
+
+			p.planPullZoneChildren(desired, &resources.PullZone{})
+			continue
+		}
+
+		var fields []FieldChange
+		if current.OriginUrl != desired.OriginUrl {
+			fields = append(fields, FieldChange{Field: "OriginUrl", Old: current.OriginUrl, New: desired.OriginUrl})
+		}
+		if desired.Type != nil && current.Type != *desired.Type {
+			fields = append(fields, FieldChange{Field: "Type", Old: current.Type, New: *desired.Type})
+		}
+		fields = append(fields, settingChanges(&desired.Cache, current)...)
+
+		if len(fields) > 0 {
+			original := *current
+			modified := *current
+			modified.OriginUrl = desired.OriginUrl
+			if desired.Type != nil {
+				modified.Type = *desired.Type
+			}
+			applySettings(&desired.Cache, &modified)
+
+			p.add(phasePullZone, ActionUpdate, address, fields, func(ctx context.Context, state *applyState) error {
+				_, err := state.client.PullZone.Patch(ctx, original.Id, &original, &modified)
+				return err
+			})
+		}
+
+		p.planPullZoneChildren(desired, current)
+	}
+
+	if !p.options.Prune {
+		return
+	}
+
+	for _, zone := range live {
+		if declared[strings.ToLower(zone.Name)] {
+			continue
+		}
+		id := zone.Id
+		p.add(phasePullZoneDelete, ActionDelete, "pullzone."+zone.Name, nil, func(ctx context.Context, state *applyState) error {
+			return state.client.PullZone.Delete(ctx, id)
+		})
+	}
+}
+
+// planPullZoneChildren plans the changes of the hostnames, access lists and edge rules of a pull zone
+func (p *planner) planPullZoneChildren(desired PullZoneConfig, current *resources.PullZone) {
+	key := strings.ToLower(desired.Name)
+	address := "pullzone." + desired.Name
+
+	if desired.Hostnames != nil {
+		var live []string
+		for _, hostname := range current.Hostnames {
+			if !hostname.IsSystemHostname {
+				live = append(live, hostname.Value)
+			}
+		}
+		p.planList(key, address+".hostname", desired.Hostnames, live,
+			func(ctx context.Context, svc *resources.PullZoneService, id int64, value string) error {
+				return svc.AddHostname(ctx, id, resources.AddHostnameOptions{Hostname: value})
+			},
+			func(ctx context.Context, svc *resources.PullZoneService, id int64, value string) error {
+				return svc.RemoveHostname(ctx, id, resources.RemoveHostnameOptions{Hostname: value})
+			})
+	}
+
+	if desired.AllowedReferrers != nil {
+		p.planList(key, address+".allowed_referrer", desired.AllowedReferrers, current.AllowedReferrers,
+			func(ctx context.Context, svc *resources.PullZoneService, id int64, value string) error {
+				return svc.AddAllowedReferrer(ctx, id, resources.HostnameOptions{Hostname: value})
+			},
+			func(ctx context.Context, svc *resources.PullZoneService, id int64, value string) error {
+				return svc.RemoveAllowedReferrer(ctx, id, resources.HostnameOptions{Hostname: value})
+			})
+	}
+
+	if desired.BlockedReferrers != nil {
+		p.planList(key, address+".blocked_referrer", desired.BlockedReferrers, current.BlockedReferrers,
+			func(ctx context.Context, svc *resources.PullZoneService, id int64, value string) error {
+				return svc.AddBlockedReferrer(ctx, id, resources.HostnameOptions{Hostname: value})
+			},
+			func(ctx context.Context, svc *resources.PullZoneService, id int64, value string) error {
+				return svc.RemoveBlockedReferrer(ctx, id, resources.HostnameOptions{Hostname: value})
+			})
+	}
+
+	if desired.BlockedIps != nil {
+		p.planList(key, address+".blocked_ip", desired.BlockedIps, current.BlockedIps,
+			func(ctx context.Context, svc *resources.PullZoneService, id int64, value string) error {
+				return svc.AddBlockedIP(ctx, id, resources.BlockedIPOptions{BlockedIp: value})
+			},
+			func(ctx context.Context, svc *resources.PullZoneService, id int64, value string) error {
+				return svc.RemoveBlockedIP(ctx, id, resources.BlockedIPOptions{BlockedIp: value})
+			})
+	}
+
+	if desired.EdgeRules != nil {
+		p.planEdgeRules(key, address, desired.EdgeRules, current.EdgeRules)
+	}
+}
+
+// listFunc adds or removes a single value of a pull zone list
+type listFunc func(ctx context.Context, svc *resources.PullZoneService, id int64, value string) error
+
+// planList plans the additions and removals of a case-insensitive pull zone list
+func (p *planner) planList(zoneKey, address string, desired, live []string, add, remove listFunc) {
+	liveSet := make(map[string]bool, len(live))
+	for _, value := range live {
+		liveSet[strings.ToLower(value)] = true
+	}
+
+	desiredSet := make(map[string]bool, len(desired))
+	for _, value := range desired {
+		lower := strings.ToLower(value)
+		if desiredSet[lower] {
+			continue
+		}
+		desiredSet[lower] = true
+		if liveSet[lower] {
+			continue
+		}
+		p.add(phasePullZoneChild, ActionCreate, fmt.Sprintf("%s[%s]", address, value), nil, func(ctx context.Context, state *applyState) error {
+			id, err := state.pullZoneID(zoneKey)
+			if err != nil {
+				return err
+			}
+			return add(ctx, state.client.PullZone, id, value)
+		})
+	}
+
+	for _, value := range live {
+		if desiredSet[strings.ToLower(value)] {
+			continue
+		}
+		p.add(phasePullZoneChildDelete, ActionDelete, fmt.Sprintf("%s[%s]", address, value), nil, func(ctx context.Context, state *applyState) error {
+			id, err := state.pullZoneID(zoneKey)
+			if err != nil {
+				return err
+			}
+			return remove(ctx, state.client.PullZone, id, value)
+		})
+	}
+}
+
+// planEdgeRules plans the changes of the edge rules of a pull zone
+func (p *planner) planEdgeRules(zoneKey, address string, desired []EdgeRuleConfig, live []resources.EdgeRule) {
+	matched := make(map[string]bool)
+
+	for _, rule := range desired {
+		options := edgeRuleOptions(rule)
+		ruleAddress := fmt.Sprintf("%s.edge_rule[%s]", address, edgeRuleKey(rule))
+
+		current := findEdgeRule(live, rule)
+		if current == nil {
+			fields := []FieldChange{
+				{Field: "ActionType", New: options.ActionType},
+				{Field: "Triggers", New: options.Triggers},
+				{Field: "Enabled", New: options.Enabled},
+			}
+			p.add(phasePullZoneChild, ActionCreate, ruleAddress, fields, func(ctx context.Context, state *applyState) error {
+				id, err := state.pullZoneID(zoneKey)
+				if err != nil {
+					return err
+				}
+				return state.client.PullZone.AddOrUpdateEdgeRule(ctx, id, options)
+			})
+			continue
+		}
+
+		matched[current.Guid] = true
+		options.Guid = current.Guid
+		if rule.Description == "" {
+			options.Description = current.Description
+		}
+
+		fields := edgeRuleChanges(current, options)
+		if len(fields) == 0 {
+			continue
+		}
+		p.add(phasePullZoneChild, ActionUpdate, ruleAddress, fields, func(ctx context.Context, state *applyState) error {
+			id, err := state.pullZoneID(zoneKey)
+			if err != nil {
+				return err
+			}
+			return state.client.PullZone.AddOrUpdateEdgeRule(ctx, id, options)
+		})
+	}
+
+	for _, rule := range live {
+		if matched[rule.Guid] {
+			continue
+		}
+		guid := rule.Guid
+		name := rule.Description
+		if name == "" {
+			name = guid
+		}
+		p.add(phasePullZoneChildDelete, ActionDelete, fmt.Sprintf("%s.edge_rule[%s]", address, name), nil, func(ctx context.Context, state *applyState) error {
+			id, err := state.pullZoneID(zoneKey)
+			if err != nil {
+				return err
+			}
+			return state.client.PullZone.DeleteEdgeRule(ctx, id, guid)
+		})
+	}
+}
+
+// planDNSZones plans the changes of all DNS zones and their records
+func (p *planner) planDNSZones(ctx context.Context, client *bunnynet.Client, live []resources.DNSZone) error {
+	declared := make(map[string]bool)
+
+	for _, desired := range p.config.DNSZones {
+		key := common.NormalizeDomain(desired.Domain)
+		declared[key] = true
+		address := "dnszone." + key
+
+		if err := p.checkPullZoneReferences(desired); err != nil {
+			return err
+		}
+
+		current := findDNSZone(live, desired.Domain)
+		if current == nil {
+			p.add(phaseDNSZone, ActionCreate, address, []FieldChange{{Field: "Domain", New: key}}, func(ctx context.Context, state *applyState) error {
+				zone, err := state.client.DNSZone.Add(ctx, resources.AddDNSZoneOptions{Domain: key})
+				if err != nil {
+					return err
+				}
+				state.dnsZoneIDs[key] = zone.Id
+				return nil
+			})
+			if desired.Records != nil {
+				p.planDNSRecords(key, address, desired.Records, nil)
+			}
+			continue
+		}
+
+		p.plan.dnsZoneIDs[key] = current.Id
+		if desired.Records == nil {
+			continue
+		}
+
+		zone, err := client.DNSZone.Get(ctx, current.Id)
+		if err != nil {
+			return err
+		}
+		p.planDNSRecords(key, address, desired.Records, zone.Records)
+	}
+
+	if !p.options.Prune {
+		return nil
+	}
+
+	for _, zone := range live {
+		key := common.NormalizeDomain(zone.Domain)
+		if declared[key] {
+			continue
+		}
+		id := zone.Id
+		p.add(phaseDNSZoneDelete, ActionDelete, "dnszone."+key, nil, func(ctx context.Context, state *applyState) error {
+			return state.client.DNSZone.Delete(ctx, id)
+		})
+	}
+
+	return nil
+}
+
+// checkPullZoneReferences verifies that PullZone records reference known pull zones
+func (p *planner) checkPullZoneReferences(zone DNSZoneConfig) error {
+	for _, record := range zone.Records {
+		if record.PullZone == "" {
+			continue
+		}
+		key := strings.ToLower(record.PullZone)
+		if _, ok := p.plan.pullZoneIDs[key]; ok {
+			continue
+		}
+		declared := false
+		for _, pullZone := range p.config.PullZones {
+			if strings.ToLower(pullZone.Name) == key {
+				declared = true
+				break
+			}
+		}
+		if !declared {
+			return fmt.Errorf("DNS zone %q: record %q references unknown pull zone %q", zone.Domain, record.Name, record.PullZone)
+		}
+	}
+	return nil
+}
+
+// planDNSRecords plans the changes of the records of a DNS zone
+func (p *planner) planDNSRecords(zoneKey, address string, desired []DNSRecordConfig, live []resources.DNSRecord) {
+	liveByKey := make(map[string]resources.DNSRecord, len(live))
+	for _, record := range live {
+		liveByKey[dnsRecordKey(record)] = record
+	}
+
+	matched := make(map[int64]bool)
+	for _, record := range desired {
+		key := dnsRecordConfigKey(record)
+		recordAddress := fmt.Sprintf("%s.record[%s]", address, key)
+
+		current, ok := liveByKey[key]
+		if !ok {
+			p.add(phaseDNSRecord, ActionCreate, recordAddress, dnsRecordChanges(nil, record), func(ctx context.Context, state *applyState) error {
+				zoneId, err := state.dnsZoneID(zoneKey)
+				if err != nil {
+					return err
+				}
+				options := resources.AddDNSRecordOptions{
+					Type:     record.Type,
+					Name:     normalizeRecordName(record.Name),
+					Value:    record.Value,
+					Ttl:      record.Ttl,
+					Priority: record.Priority,
+					Weight:   record.Weight,
+					Port:     record.Port,
+					Flags:    record.Flags,
+					Tag:      record.Tag,
+					Disabled: record.Disabled,
+					Comment:  record.Comment,
+				}
+				if record.PullZone != "" {
+					if options.PullZoneId, err = state.pullZoneID(strings.ToLower(record.PullZone)); err != nil {
+						return err
+					}
+				}
+				_, err = state.client.DNSZone.AddRecord(ctx, zoneId, options)
+				return err
+			})
+			continue
+		}
+
+		matched[current.Id] = true
+		fields := dnsRecordChanges(&current, record)
+		if len(fields) == 0 {
+			continue
+		}
+		recordId := current.Id
+		p.add(phaseDNSRecord, ActionUpdate, recordAddress, fields, func(ctx context.Context, state *applyState) error {
+			zoneId, err := state.dnsZoneID(zoneKey)
+			if err != nil {
+				return err
+			}
+			options := resources.UpdateDNSRecordOptions{
+				Id:       recordId,
+				Type:     common.Ptr(record.Type),
+				Name:     common.Ptr(normalizeRecordName(record.Name)),
+				Value:    common.Ptr(record.Value),
+				Priority: common.Ptr(record.Priority),
+				Weight:   common.Ptr(record.Weight),
+				Port:     common.Ptr(record.Port),
+				Flags:    common.Ptr(record.Flags),
+				Tag:      common.Ptr(record.Tag),
+				Disabled: common.Ptr(record.Disabled),
+				Comment:  common.Ptr(record.Comment),
+			}
+			if record.Ttl != 0 {
+				options.Ttl = common.Ptr(record.Ttl)
+			}
+			return state.client.DNSZone.UpdateRecord(ctx, zoneId, recordId, options)
+		})
+	}
+
+	for _, record := range live {
+		if matched[record.Id] {
+			continue
+		}
+		recordId := record.Id
+		p.add(phaseDNSRecordDelete, ActionDelete, fmt.Sprintf("%s.record[%s]", address, dnsRecordKey(record)), nil, func(ctx context.Context, state *applyState) error {
+			zoneId, err := state.dnsZoneID(zoneKey)
+			if err != nil {
+				return err
+			}
+			return state.client.DNSZone.DeleteRecord(ctx, zoneId, recordId)
+		})
+	}
+}
+
+// findPullZone returns the live pull zone with the given name
+func findPullZone(zones []resources.PullZone, name string) *resources.PullZone {
+	for i := range zones {
+		if strings.EqualFold(zones[i].Name, name) {
+			return &zones[i]
+		}
+	}
+	return nil
+}
+
+// findDNSZone returns the live DNS zone for the given domain
+func findDNSZone(zones []resources.DNSZone, domain string) *resources.DNSZone {
+	domain = common.NormalizeDomain(domain)
+	for i := range zones {
+		if common.NormalizeDomain(zones[i].Domain) == domain {
+			return &zones[i]
+		}
+	}
+	return nil
+}
+
+// findEdgeRule returns the live edge rule matching the configured rule
+func findEdgeRule(rules []resources.EdgeRule, rule EdgeRuleConfig) *resources.EdgeRule {
+	for i := range rules {
+		if rule.Guid != "" {
+			if strings.EqualFold(rules[i].Guid, rule.Guid) {
+				return &rules[i]
+			}
+			continue
+		}
+		if rules[i].Description == rule.Description {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// edgeRuleKey returns the identifier of a configured edge rule
+func edgeRuleKey(rule EdgeRuleConfig) string {
+	if rule.Guid != "" {
+		return rule.Guid
+	}
+	return rule.Description
+}
+
+// edgeRuleOptions converts a configured edge rule into API options
+func edgeRuleOptions(rule EdgeRuleConfig) resources.AddOrUpdateEdgeRuleOptions {
+	enabled := true
+	if rule.Enabled != nil {
+		enabled = *rule.Enabled
+	}
+
+	triggers := make([]resources.EdgeRuleTrigger, 0, len(rule.Triggers))
+	for _, trigger := range rule.Triggers {
+		triggers = append(triggers, resources.EdgeRuleTrigger{
+			Type:                trigger.Type,
+			PatternMatches:      trigger.PatternMatches,
+			PatternMatchingType: trigger.PatternMatchingType,
+			Parameter1:          trigger.Parameter1,
+			TriggerMatchingType: trigger.TriggerMatchingType,
+		})
+	}
+
+	return resources.AddOrUpdateEdgeRuleOptions{
+		Guid:                rule.Guid,
+		ActionType:          rule.ActionType,
+		ActionParameter1:    rule.ActionParameter1,
+		ActionParameter2:    rule.ActionParameter2,
+		Triggers:            triggers,
+		TriggerMatchingType: rule.TriggerMatchingType,
+		Description:         rule.Description,
+		Enabled:             enabled,
+	}
+}
+
+// edgeRuleChanges compares a live edge rule with the desired options
+func edgeRuleChanges(current *resources.EdgeRule, desired resources.AddOrUpdateEdgeRuleOptions) []FieldChange {
+	var fields []FieldChange
+	if current.ActionType != desired.ActionType {
+		fields = append(fields, FieldChange{Field: "ActionType", Old: current.ActionType, New: desired.ActionType})
+	}
+	if current.ActionParameter1 != desired.ActionParameter1 {
+		fields = append(fields, FieldChange{Field: "ActionParameter1", Old: current.ActionParameter1, New: desired.ActionParameter1})
+	}
+	if current.ActionParameter2 != desired.ActionParameter2 {
+		fields = append(fields, FieldChange{Field: "ActionParameter2", Old: current.ActionParameter2, New: desired.ActionParameter2})
+	}
+	if current.Description != desired.Description {
+		fields = append(fields, FieldChange{Field: "Description", Old: current.Description, New: desired.Description})
+	}
+	if current.Enabled != desired.Enabled {
+		fields = append(fields, FieldChange{Field: "Enabled", Old: current.Enabled, New: desired.Enabled})
+	}
+	if current.TriggerMatchingType != desired.TriggerMatchingType {
+		fields = append(fields, FieldChange{Field: "TriggerMatchingType", Old: current.TriggerMatchingType, New: desired.TriggerMatchingType})
+	}
+	if !equalTriggers(current.Triggers, desired.Triggers) {
+		fields = append(fields, FieldChange{Field: "Triggers", Old: current.Triggers, New: desired.Triggers})
+	}
+	return fields
+}
+
+// equalTriggers compares two trigger lists, treating nil and empty pattern lists as equal
+func equalTriggers(a, b []resources.EdgeRuleTrigger) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		x, y := a[i], b[i]
+		if x.Type != y.Type || x.PatternMatchingType != y.PatternMatchingType ||
+			x.Parameter1 != y.Parameter1 || x.TriggerMatchingType != y.TriggerMatchingType {
+			return false
+		}
+		if !equalStrings(x.PatternMatches, y.PatternMatches) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalStrings compares two string slices, treating nil and empty as equal
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeRecordName maps the apex name "@" to the empty name used by the API
+func normalizeRecordName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "@" {
+		return ""
+	}
+	return name
+}
+
+// dnsRecordKey returns the identity of a live DNS record
+func dnsRecordKey(record resources.DNSRecord) string {
+	value := record.Value
+	if record.Type == resources.DNSRecordTypePullZone {
+		value = strings.ToLower(record.LinkName)
+	}
+	return recordKey(record.Name, record.Type, value)
+}
+
+// dnsRecordConfigKey returns the identity of a configured DNS record
+func dnsRecordConfigKey(record DNSRecordConfig) string {
+	value := record.Value
+	if record.Type == resources.DNSRecordTypePullZone {
+		value = strings.ToLower(record.PullZone)
+	}
+	return recordKey(record.Name, record.Type, value)
+}
+
+// recordKey formats the (Name, Type, Value) identity of a DNS record
+func recordKey(name string, recordType resources.DNSRecordType, value string) string {
+	name = normalizeRecordName(name)
+	if name == "" {
+		name = "@"
+	}
+	return fmt.Sprintf("%s %d %s", name, recordType, value)
+}
+
+// dnsRecordChanges compares a live DNS record with the configured record.
+// When current is nil all non-zero configured fields are reported as new.
+func dnsRecordChanges(current *resources.DNSRecord, desired DNSRecordConfig) []FieldChange {
+	var live resources.DNSRecord
+	if current != nil {
+		live = *current
+	}
+
+	var fields []FieldChange
+	compare := func(field string, old, new interface{}) {
+		if current == nil {
+			if !reflect.ValueOf(new).IsZero() {
+				fields = append(fields, FieldChange{Field: field, New: new})
+			}
+			return
+		}
+		if old != new {
+			fields = append(fields, FieldChange{Field: field, Old: old, New: new})
+		}
+	}
+
+	// An omitted TTL leaves the TTL of an existing record unchanged
+	if desired.Ttl != 0 {
+		compare("Ttl", live.Ttl, desired.Ttl)
+	}
+	compare("Priority", live.Priority, desired.Priority)
+	compare("Weight", live.Weight, desired.Weight)
+	compare("Port", live.Port, desired.Port)
+	compare("Flags", live.Flags, desired.Flags)
+	compare("Tag", live.Tag, desired.Tag)
+	compare("Disabled", live.Disabled, desired.Disabled)
+	compare("Comment", live.Comment, desired.Comment)
+
+	return fields
+}
+
+// settingChanges compares the set fields of a settings struct with the matching PullZone fields.
+// When zone is nil all set fields are reported as new.
+func settingChanges(settings interface{}, zone *resources.PullZone) []FieldChange {
+	sv := reflect.ValueOf(settings).Elem()
+	var zv reflect.Value
+	if zone != nil {
+		zv = reflect.ValueOf(zone).Elem()
+	}
+
+	var fields []FieldChange
+	for i := 0; i < sv.NumField(); i++ {
+		field := sv.Field(i)
+		if field.IsNil() {
+			continue
+		}
+		name := sv.Type().Field(i).Name
+		desired := field
+		if field.Kind() == reflect.Ptr {
+			desired = field.Elem()
+		}
+
+		if zone == nil {
+			fields = append(fields, FieldChange{Field: name, New: desired.Interface()})
+			continue
+		}
+
+		current := zv.FieldByName(name)
+		if equalValues(current, desired) {
+			continue
+		}
+		fields = append(fields, FieldChange{Field: name, Old: current.Interface(), New: desired.Interface()})
+	}
+	return fields
+}
+
+// applySettings copies the set fields of a settings struct onto a PullZone
+func applySettings(settings interface{}, zone *resources.PullZone) {
+	sv := reflect.ValueOf(settings).Elem()
+	zv := reflect.ValueOf(zone).Elem()
+	for i := 0; i < sv.NumField(); i++ {
+		field := sv.Field(i)
+		if field.IsNil() {
+			continue
+		}
+		if field.Kind() == reflect.Ptr {
+			field = field.Elem()
+		}
+		zv.FieldByName(sv.Type().Field(i).Name).Set(field)
+	}
+}
+
+// hasSettings returns true if at least one field of a settings struct is set
+func hasSettings(settings interface{}) bool {
+	sv := reflect.ValueOf(settings).Elem()
+	for i := 0; i < sv.NumField(); i++ {
+		if !sv.Field(i).IsNil() {
+			return true
+		}
+	}
+	return false
+}
+
+// equalValues compares two reflected values, treating nil and empty slices as equal
+func equalValues(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Slice && b.Kind() == reflect.Slice && a.Len() == 0 && b.Len() == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
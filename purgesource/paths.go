@@ -0,0 +1,143 @@
+package purgesource
+
+import (
+	"bufio"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// PathOptions represents the options for mapping file paths to public URLs
+type PathOptions struct {
+	// Root is the directory that is served at the root of the site, such as "public" or "dist".
+	// Paths outside it are ignored.
+	Root string
+
+	// Scheme is the scheme of the URLs, defaults to https
+	Scheme string
+
+	// IncludeSystemHostnames also maps paths to the b-cdn.net hostnames managed by bunny.net
+	IncludeSystemHostnames bool
+
+	// IndexFiles is the list of file names served for a directory, such as "index.html". A changed
+	// index file also purges the URL of its directory.
+	IndexFiles []string
+}
+
+// URLsForPaths maps changed file paths to their public URLs on every hostname of the Pull Zone
+func URLsForPaths(zone *resources.PullZone, paths []string, opts *PathOptions) []string {
+	if opts == nil {
+		opts = &PathOptions{}
+	}
+
+	var urls []string
+	for _, hostname := range hostnames(zone, opts.IncludeSystemHostnames) {
+		for _, p := range paths {
+			for _, urlPath := range publicPaths(p, opts) {
+				urls = append(urls, buildURL(opts.Scheme, hostname, urlPath))
+			}
+		}
+	}
+	return urls
+}
+
+// WildcardURLs returns wildcard URLs purging everything under a directory on every hostname of the Pull Zone,
+// such as a directory of the storage zone behind it. An empty directory purges the whole site.
+func WildcardURLs(zone *resources.PullZone, directory string, opts *PathOptions) []string {
+	if opts == nil {
+		opts = &PathOptions{}
+	}
+
+	directory = strings.Trim(path.Clean("/"+directory), "/")
+	prefix := "/"
+	if directory != "" {
+		prefix = "/" + directory + "/"
+	}
+
+	var urls []string
+	for _, hostname := range hostnames(zone, opts.IncludeSystemHostnames) {
+		urls = append(urls, buildURL(opts.Scheme, hostname, prefix)+"*")
+	}
+	return urls
+}
+
+// ParsePathList parses a list of changed paths, one per line, such as the output of
+// "git diff --name-only" or "git diff --name-status". Both paths of a rename are returned.
+func ParsePathList(r io.Reader) ([]string, error) {
+	var paths []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) > 1 && isGitStatus(fields[0]) {
+			fields = fields[1:]
+		}
+		for _, field := range fields {
+			if field = strings.TrimSpace(field); field != "" {
+				paths = append(paths, field)
+			}
+		}
+	}
+	return paths, scanner.Err()
+}
+
+// isGitStatus reports whether the field is a git diff --name-status code such as M, A, D or R100
+func isGitStatus(field string) bool {
+	if field == "" || !strings.ContainsRune("ACDMRTUX", rune(field[0])) {
+		return false
+	}
+	for _, c := range field[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// publicPaths returns the URL paths serving a file path, or nothing when it is outside the root
+func publicPaths(p string, opts *PathOptions) []string {
+	p = path.Clean("/" + strings.ReplaceAll(strings.TrimSpace(p), "\\", "/"))
+	if opts.Root != "" {
+		root := path.Clean("/" + opts.Root)
+		if !strings.HasPrefix(p, root+"/") {
+			return nil
+		}
+		p = strings.TrimPrefix(p, root)
+	}
+
+	paths := []string{p}
+	for _, index := range opts.IndexFiles {
+		if path.Base(p) == index {
+			paths = append(paths, strings.TrimSuffix(p, index))
+		}
+	}
+	return paths
+}
+
+// hostnames returns the hostnames of the zone to build URLs for
+func hostnames(zone *resources.PullZone, includeSystem bool) []string {
+	var names []string
+	for _, hostname := range zone.Hostnames {
+		if hostname.IsSystemHostname && !includeSystem {
+			continue
+		}
+		names = append(names, hostname.Value)
+	}
+	return names
+}
+
+// buildURL builds a URL with an escaped path
+func buildURL(scheme, host, urlPath string) string {
+	if scheme == "" {
+		scheme = "https"
+	}
+	u := url.URL{Scheme: scheme, Host: host, Path: urlPath}
+	return u.String()
+}
@@ -0,0 +1,133 @@
+// Package purgesource derives purge targets from sitemaps, changed file paths and storage directories,
+// for use with PurgeService.PurgeMany after a deploy
+package purgesource
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// maxSitemapDepth is the deepest nesting of sitemap indexes that is followed
+	maxSitemapDepth = 3
+
+	// maxSitemapSize is the largest sitemap accepted, as defined by the sitemap protocol
+	maxSitemapSize = 50 << 20
+)
+
+// sitemapDocument is either a urlset or a sitemapindex document
+type sitemapDocument struct {
+	XMLName  xml.Name
+	URLs     []sitemapLocation `xml:"url"`
+	Sitemaps []sitemapLocation `xml:"sitemap"`
+}
+
+// sitemapLocation is a url or sitemap entry of a sitemap document
+type sitemapLocation struct {
+	Loc string `xml:"loc"`
+}
+
+// ParseSitemap parses a sitemap document. For a urlset the page URLs are returned, for a
+// sitemap index the URLs of the child sitemaps are returned in sitemaps.
+func ParseSitemap(r io.Reader) (urls, sitemaps []string, err error) {
+	var doc sitemapDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("purgesource: invalid sitemap: %w", err)
+	}
+
+	switch doc.XMLName.Local {
+	case "urlset":
+		for _, entry := range doc.URLs {
+			if loc := strings.TrimSpace(entry.Loc); loc != "" {
+				urls = append(urls, loc)
+			}
+		}
+	case "sitemapindex":
+		for _, entry := range doc.Sitemaps {
+			if loc := strings.TrimSpace(entry.Loc); loc != "" {
+				sitemaps = append(sitemaps, loc)
+			}
+		}
+	default:
+		return nil, nil, fmt.Errorf("purgesource: unexpected sitemap root element %q", doc.XMLName.Local)
+	}
+
+	return urls, sitemaps, nil
+}
+
+// FetchSitemap downloads a sitemap and returns every page URL it lists, following sitemap indexes.
+// Gzip compressed sitemaps are supported. A nil client uses http.DefaultClient.
+func FetchSitemap(ctx context.Context, client *http.Client, sitemapURL string) ([]string, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	seen := map[string]bool{}
+	var urls []string
+	var fetch func(u string, depth int) error
+	fetch = func(u string, depth int) error {
+		if seen[u] {
+			return nil
+		}
+		seen[u] = true
+		if depth > maxSitemapDepth {
+			return fmt.Errorf("purgesource: sitemap %s is nested too deeply", u)
+		}
+
+		pages, children, err := fetchSitemap(ctx, client, u)
+		if err != nil {
+			return err
+		}
+		urls = append(urls, pages...)
+		for _, child := range children {
+			if err := fetch(child, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := fetch(sitemapURL, 0); err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+// fetchSitemap downloads and parses a single sitemap document
+func fetchSitemap(ctx context.Context, client *http.Client, u string) (urls, sitemaps []string, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("purgesource: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("purgesource: failed to fetch sitemap: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("purgesource: sitemap %s returned status %d", u, resp.StatusCode)
+	}
+
+	var body io.Reader = io.LimitReader(resp.Body, maxSitemapSize)
+	if strings.HasSuffix(req.URL.Path, ".gz") && resp.Header.Get("Content-Encoding") == "" {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("purgesource: invalid gzip sitemap %s: %w", u, err)
+		}
+		defer gz.Close()
+		body = io.LimitReader(gz, maxSitemapSize)
+	}
+
+	urls, sitemaps, err = ParseSitemap(body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w (%s)", err, u)
+	}
+	return urls, sitemaps, nil
+}
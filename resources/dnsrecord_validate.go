@@ -0,0 +1,256 @@
+package resources
+
+import (
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/common"
+)
+
+const (
+	// minRecordTtl is the lowest TTL accepted for a DNS record, 0 selects the automatic TTL
+	minRecordTtl = 15
+
+	// maxTXTStringLength is the maximum length of a single TXT character string
+	maxTXTStringLength = 255
+
+	// maxTXTLength is the maximum total length of a TXT record value
+	maxTXTLength = 65280
+
+	// maxHostnameLength is the maximum length of a domain name
+	maxHostnameLength = 253
+
+	// maxLabelLength is the maximum length of a single domain name label
+	maxLabelLength = 63
+)
+
+// caaTags is the list of CAA property tags defined by RFC 8659
+var caaTags = map[string]bool{"issue": true, "issuewild": true, "iodef": true}
+
+// dnsRecordFields holds the fields of a record being validated, nil fields are not set
+type dnsRecordFields struct {
+	Type       *DNSRecordType
+	Ttl        *int32
+	Value      *string
+	Name       *string
+	Weight     *int32
+	Priority   *int32
+	Flags      *int
+	Tag        *string
+	Port       *int32
+	PullZoneId *int64
+	ScriptId   *int64
+}
+
+// Validate checks the record options for values the API would reject, returning all field errors together
+func (o AddDNSRecordOptions) Validate() error {
+	errs := &common.ValidationError{}
+	validateDNSRecord(errs, dnsRecordFields{
+		Type:       &o.Type,
+		Ttl:        &o.Ttl,
+		Value:      &o.Value,
+		Name:       &o.Name,
+		Weight:     &o.Weight,
+		Priority:   &o.Priority,
+		Flags:      &o.Flags,
+		Tag:        &o.Tag,
+		Port:       &o.Port,
+		PullZoneId: &o.PullZoneId,
+		ScriptId:   &o.ScriptId,
+	}, true)
+	return errs.ErrorOrNil()
+}
+
+// Validate checks the fields that are set for values the API would reject, returning all field errors together.
+// Value formats can only be checked when Type is set.
+func (o UpdateDNSRecordOptions) Validate() error {
+	errs := &common.ValidationError{}
+	if o.Id <= 0 {
+		errs.Add("Id", "a record ID is required")
+	}
+	validateDNSRecord(errs, dnsRecordFields{
+		Type:       o.Type,
+		Ttl:        o.Ttl,
+		Value:      o.Value,
+		Name:       o.Name,
+		Weight:     o.Weight,
+		Priority:   o.Priority,
+		Flags:      o.Flags,
+		Tag:        o.Tag,
+		Port:       o.Port,
+		PullZoneId: o.PullZoneId,
+		ScriptId:   o.ScriptId,
+	}, false)
+	return errs.ErrorOrNil()
+}
+
+// SplitTXTValue splits a TXT value into the 255 byte character strings used on the wire
+func SplitTXTValue(value string) []string {
+	var chunks []string
+	for len(value) > maxTXTStringLength {
+		chunks = append(chunks, value[:maxTXTStringLength])
+		value = value[maxTXTStringLength:]
+	}
+	return append(chunks, value)
+}
+
+// validateDNSRecord checks the set fields of a record. When complete is true the record is being
+// added and fields required by its type must be present.
+func validateDNSRecord(errs *common.ValidationError, f dnsRecordFields, complete bool) {
+	// Record types added to the API after this package are passed through without checking their value
+	if f.Type != nil && *f.Type < DNSRecordTypeA {
+		errs.Add("Type", "unknown record type %d", *f.Type)
+	}
+	if f.Ttl != nil && *f.Ttl != 0 && *f.Ttl < minRecordTtl {
+		errs.Add("Ttl", "must be 0 for automatic or at least %d seconds", minRecordTtl)
+	}
+	if f.Name != nil && !validRecordName(*f.Name) {
+		errs.Add("Name", "%q is not a valid record name", *f.Name)
+	}
+	for _, field := range []struct {
+		name  string
+		value *int32
+	}{{"Weight", f.Weight}, {"Priority", f.Priority}, {"Port", f.Port}} {
+		if field.value != nil && (*field.value < 0 || *field.value > 65535) {
+			errs.Add(field.name, "must be between 0 and 65535")
+		}
+	}
+	if f.Flags != nil && (*f.Flags < 0 || *f.Flags > 255) {
+		errs.Add("Flags", "must be between 0 and 255")
+	}
+
+	if f.Type == nil {
+		return
+	}
+
+	value := ""
+	if f.Value != nil {
+		value = *f.Value
+	}
+	requireValue := complete || f.Value != nil
+
+	switch *f.Type {
+	case DNSRecordTypeA:
+		if requireValue {
+			if addr, err := netip.ParseAddr(value); err != nil || !addr.Is4() {
+				errs.Add("Value", "%q is not an IPv4 address", value)
+			}
+		}
+	case DNSRecordTypeAAAA:
+		if requireValue {
+			if addr, err := netip.ParseAddr(value); err != nil || !addr.Is6() || addr.Is4In6() {
+				errs.Add("Value", "%q is not an IPv6 address", value)
+			}
+		}
+	case DNSRecordTypeCNAME, DNSRecordTypeNS, DNSRecordTypePTR, DNSRecordTypeFlatten:
+		// CNAME and Flatten records may point at the zone apex with "@"
+		apexTarget := value == "@" && (*f.Type == DNSRecordTypeCNAME || *f.Type == DNSRecordTypeFlatten)
+		if requireValue && !apexTarget && !validHostname(value) {
+			errs.Add("Value", "%q is not a valid hostname", value)
+		}
+		if *f.Type == DNSRecordTypeCNAME && f.Name != nil && isApex(*f.Name) {
+			errs.Add("Name", "a CNAME record cannot be created at the zone apex, use a Flatten record instead")
+		}
+	case DNSRecordTypeMX:
+		// A preference of 0 is valid and common, the range of Priority is checked above
+		if requireValue && !validHostname(value) {
+			errs.Add("Value", "%q is not a valid mail server hostname", value)
+		}
+	case DNSRecordTypeSRV:
+		// A target of "." means the service is not available (RFC 2782), it then has port 0
+		if requireValue && value != "." && !validHostname(value) {
+			errs.Add("Value", "%q is not a valid target hostname", value)
+		}
+		if f.Name != nil && !strings.HasPrefix(*f.Name, "_") {
+			errs.Add("Name", "an SRV record name must have the form _service._proto")
+		}
+	case DNSRecordTypeCAA:
+		tag := ""
+		if f.Tag != nil {
+			tag = strings.ToLower(*f.Tag)
+		}
+		if (complete || f.Tag != nil) && !caaTags[tag] {
+			errs.Add("Tag", "%q is not a CAA tag, expected issue, issuewild or iodef", tag)
+		}
+		if requireValue && value == "" && tag != "issue" && tag != "issuewild" {
+			errs.Add("Value", "a CAA value is required")
+		}
+		if tag == "iodef" && value != "" {
+			if u, err := url.Parse(value); err != nil || (u.Scheme != "mailto" && u.Scheme != "http" && u.Scheme != "https") {
+				errs.Add("Value", "an iodef value must be a mailto, http or https URL")
+			}
+		}
+		if f.Flags != nil && *f.Flags != 0 && *f.Flags != 128 {
+			errs.Add("Flags", "a CAA flag must be 0 or 128 (critical)")
+		}
+	case DNSRecordTypeTXT:
+		if requireValue && value == "" {
+			errs.Add("Value", "a TXT value is required")
+		}
+		if len(value) > maxTXTLength {
+			errs.Add("Value", "a TXT value cannot be longer than %d bytes", maxTXTLength)
+		}
+	case DNSRecordTypeRedirect:
+		if requireValue {
+			if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+				errs.Add("Value", "%q is not an absolute URL", value)
+			}
+		}
+	case DNSRecordTypePullZone:
+		if complete && (f.PullZoneId == nil || *f.PullZoneId <= 0) {
+			errs.Add("PullZoneId", "a PullZone record requires a pull zone ID")
+		}
+	case DNSRecordTypeScript:
+		if complete && (f.ScriptId == nil || *f.ScriptId <= 0) {
+			errs.Add("ScriptId", "a Script record requires a script ID")
+		}
+	}
+}
+
+// isApex reports whether a record name refers to the zone apex
+func isApex(name string) bool {
+	name = strings.TrimSpace(name)
+	return name == "" || name == "@"
+}
+
+// validRecordName reports whether a name relative to the zone is valid, allowing @, underscores and a leading wildcard
+func validRecordName(name string) bool {
+	if isApex(name) {
+		return true
+	}
+	if strings.HasPrefix(name, "*.") {
+		name = name[2:]
+	} else if name == "*" {
+		return true
+	}
+	return validDomainName(name)
+}
+
+// validHostname reports whether the value is a valid hostname, with or without a trailing dot
+func validHostname(value string) bool {
+	return validDomainName(strings.TrimSuffix(value, "."))
+}
+
+// validDomainName checks the length and characters of every label of a domain name
+func validDomainName(name string) bool {
+	if name == "" || len(name) > maxHostnameLength {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > maxLabelLength {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			switch {
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_':
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -177,6 +177,12 @@ type DNSRecord struct {
 	// LinkName is the link name of the DNS record
 	LinkName string `json:"LinkName"`
 
+	// PullZoneId is the ID of the pull zone a PullZone record points to, 0 when the API does not report it
+	PullZoneId int64 `json:"PullZoneId,omitempty"`
+
+	// ScriptId is the ID of the script a Script record runs, 0 when the API does not report it
+	ScriptId int64 `json:"ScriptId,omitempty"`
+
 	// IPGeoLocationInfo is the IP geolocation information of the DNS record
 	IPGeoLocationInfo IPGeoLocationInfo `json:"IPGeoLocationInfo"`
 
@@ -274,70 +280,72 @@ type AddDNSRecordOptions struct {
 	Comment string `json:"Comment,omitempty"`
 }
 
-// UpdateDNSRecordOptions represents the options for updating a DNS record
+// UpdateDNSRecordOptions represents the options for updating a DNS record.
+// Only fields that are set are sent, so fields can be changed to their zero value,
+// such as Disabled=false or Ttl=0 for automatic TTL. Use common.Ptr to set individual fields.
 type UpdateDNSRecordOptions struct {
 	// Id is the ID of the DNS record
 	Id int64 `json:"Id"`
 
 	// Type is the type of the DNS record
-	Type DNSRecordType `json:"Type"`
+	Type *DNSRecordType `json:"Type,omitempty"`
 
 	// Ttl is the time to live of the DNS record
-	Ttl int32 `json:"Ttl,omitempty"`
+	Ttl *int32 `json:"Ttl,omitempty"`
 
 	// Value is the value of the DNS record
-	Value string `json:"Value,omitempty"`
+	Value *string `json:"Value,omitempty"`
 
 	// Name is the name of the DNS record
-	Name string `json:"Name,omitempty"`
+	Name *string `json:"Name,omitempty"`
 
 	// Weight is the weight of the DNS record
-	Weight int32 `json:"Weight,omitempty"`
+	Weight *int32 `json:"Weight,omitempty"`
 
 	// Priority is the priority of the DNS record
-	Priority int32 `json:"Priority,omitempty"`
+	Priority *int32 `json:"Priority,omitempty"`
 
 	// Flags is the flags of the DNS record
-	Flags int `json:"Flags,omitempty"`
+	Flags *int `json:"Flags,omitempty"`
 
 	// Tag is the tag of the DNS record
-	Tag string `json:"Tag,omitempty"`
+	Tag *string `json:"Tag,omitempty"`
 
 	// Port is the port of the DNS record
-	Port int32 `json:"Port,omitempty"`
+	Port *int32 `json:"Port,omitempty"`
 
 	// PullZoneId is the ID of the pull zone
-	PullZoneId int64 `json:"PullZoneId,omitempty"`
+	PullZoneId *int64 `json:"PullZoneId,omitempty"`
 
 	// ScriptId is the ID of the script
-	ScriptId int64 `json:"ScriptId,omitempty"`
+	ScriptId *int64 `json:"ScriptId,omitempty"`
 
 	// Accelerated indicates whether the DNS record should be accelerated
-	Accelerated bool `json:"Accelerated,omitempty"`
+	Accelerated *bool `json:"Accelerated,omitempty"`
 
 	// MonitorType is the monitor type of the DNS record
-	MonitorType MonitorType `json:"MonitorType,omitempty"`
+	MonitorType *MonitorType `json:"MonitorType,omitempty"`
 
 	// GeolocationLatitude is the geolocation latitude of the DNS record
-	GeolocationLatitude float64 `json:"GeolocationLatitude,omitempty"`
+	GeolocationLatitude *float64 `json:"GeolocationLatitude,omitempty"`
 
 	// GeolocationLongitude is the geolocation longitude of the DNS record
-	GeolocationLongitude float64 `json:"GeolocationLongitude,omitempty"`
+	GeolocationLongitude *float64 `json:"GeolocationLongitude,omitempty"`
 
 	// LatencyZone is the latency zone of the DNS record
-	LatencyZone string `json:"LatencyZone,omitempty"`
+	LatencyZone *string `json:"LatencyZone,omitempty"`
 
 	// SmartRoutingType is the smart routing type of the DNS record
-	SmartRoutingType SmartRoutingType `json:"SmartRoutingType,omitempty"`
+	SmartRoutingType *SmartRoutingType `json:"SmartRoutingType,omitempty"`
 
 	// Disabled indicates whether the DNS record should be disabled
-	Disabled bool `json:"Disabled,omitempty"`
+	Disabled *bool `json:"Disabled,omitempty"`
 
 	// EnvironmentalVariables is the list of environmental variables of the DNS record
-	EnvironmentalVariables []EnvironmentalVariable `json:"EnviromentalVariables,omitempty"`
+	EnvironmentalVariables *[]EnvironmentalVariable `json:"EnviromentalVariables,omitempty"`
 
 	// Comment is the comment of the DNS record
-	Comment string `json:"Comment,omitempty"`
+	Comment *string `json:"Comment,omitempty"`
 }
 
 // DNSZone represents a DNS zone in the Bunny.net API
@@ -394,28 +402,29 @@ type AddDNSZoneOptions struct {
 	Domain string `json:"Domain"`
 }
 
-// UpdateDNSZoneOptions represents the options for updating a DNS zone
+// UpdateDNSZoneOptions represents the options for updating a DNS zone.
+// Only fields that are set are sent, so settings can be turned off explicitly. Use common.Ptr to set individual fields.
 type UpdateDNSZoneOptions struct {
 	// CustomNameserversEnabled indicates whether custom nameservers should be enabled
-	CustomNameserversEnabled bool `json:"CustomNameserversEnabled,omitempty"`
+	CustomNameserversEnabled *bool `json:"CustomNameserversEnabled,omitempty"`
 
 	// Nameserver1 is the first custom nameserver
-	Nameserver1 string `json:"Nameserver1,omitempty"`
+	Nameserver1 *string `json:"Nameserver1,omitempty"`
 
 	// Nameserver2 is the second custom nameserver
-	Nameserver2 string `json:"Nameserver2,omitempty"`
+	Nameserver2 *string `json:"Nameserver2,omitempty"`
 
 	// SoaEmail is the SOA email of the DNS zone
-	SoaEmail string `json:"SoaEmail,omitempty"`
+	SoaEmail *string `json:"SoaEmail,omitempty"`
 
 	// LoggingEnabled indicates whether logging should be enabled
-	LoggingEnabled bool `json:"LoggingEnabled,omitempty"`
+	LoggingEnabled *bool `json:"LoggingEnabled,omitempty"`
 
 	// LogAnonymizationType is the type of log anonymization
-	LogAnonymizationType LogAnonymizationType `json:"LogAnonymizationType,omitempty"`
+	LogAnonymizationType *LogAnonymizationType `json:"LogAnonymizationType,omitempty"`
 
 	// LoggingIPAnonymizationEnabled indicates whether IP anonymization should be enabled for logging
-	LoggingIPAnonymizationEnabled bool `json:"LoggingIPAnonymizationEnabled,omitempty"`
+	LoggingIPAnonymizationEnabled *bool `json:"LoggingIPAnonymizationEnabled,omitempty"`
 }
 
 // DNSSecInfo represents DNSSEC information for a DNS zone
@@ -723,6 +732,10 @@ func (s *DNSZoneService) CheckAvailability(ctx context.Context, options CheckZon
 
 // AddRecord adds a DNS record to a DNS zone
 func (s *DNSZoneService) AddRecord(ctx context.Context, zoneId int64, options AddDNSRecordOptions) (*DNSRecord, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("/dnszone/%d/records", zoneId)
 	req, err := internal.NewRequest(http.MethodPut, s.baseURL, path, options, s.apiKey, s.userAgent)
 	if err != nil {
@@ -746,6 +759,13 @@ func (s *DNSZoneService) AddRecord(ctx context.Context, zoneId int64, options Ad
 
 // UpdateRecord updates a DNS record in a DNS zone
 func (s *DNSZoneService) UpdateRecord(ctx context.Context, zoneId, recordId int64, options UpdateDNSRecordOptions) error {
+	if options.Id == 0 {
+		options.Id = recordId
+	}
+	if err := options.Validate(); err != nil {
+		return err
+	}
+
 	path := fmt.Sprintf("/dnszone/%d/records/%d", zoneId, recordId)
 	req, err := internal.NewRequest(http.MethodPost, s.baseURL, path, options, s.apiKey, s.userAgent)
 	if err != nil {
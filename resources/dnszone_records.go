@@ -0,0 +1,161 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/common"
+)
+
+// DNSRecordFilter represents the criteria for filtering the records of a DNS zone.
+// Unset fields match every record.
+type DNSRecordFilter struct {
+	// Name matches the record name case-insensitively, with "@" or "" for the apex
+	Name *string
+
+	// Types matches records of any of the given types
+	Types []DNSRecordType
+
+	// Value matches the record value case-insensitively
+	Value *string
+
+	// Disabled matches records by their disabled state
+	Disabled *bool
+
+	// MonitorStatus matches records by their monitor status
+	MonitorStatus *MonitorStatus
+}
+
+// Matches reports whether the record matches the filter, a nil filter matches every record
+func (f *DNSRecordFilter) Matches(record DNSRecord) bool {
+	if f == nil {
+		return true
+	}
+	if f.Name != nil && !sameRecordName(*f.Name, record.Name) {
+		return false
+	}
+	if len(f.Types) > 0 {
+		found := false
+		for _, recordType := range f.Types {
+			if recordType == record.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.Value != nil && !strings.EqualFold(*f.Value, record.Value) {
+		return false
+	}
+	if f.Disabled != nil && *f.Disabled != record.Disabled {
+		return false
+	}
+	if f.MonitorStatus != nil && *f.MonitorStatus != record.MonitorStatus {
+		return false
+	}
+	return true
+}
+
+// ListRecords returns the records of a DNS zone that match the filter
+func (s *DNSZoneService) ListRecords(ctx context.Context, zoneId int64, filter *DNSRecordFilter) ([]DNSRecord, error) {
+	zone, err := s.Get(ctx, zoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]DNSRecord, 0, len(zone.Records))
+	for _, record := range zone.Records {
+		if filter.Matches(record) {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// GetRecord returns a DNS record by ID, or an error matching common.ErrNotFound if the zone has no such record
+func (s *DNSZoneService) GetRecord(ctx context.Context, zoneId, recordId int64) (*DNSRecord, error) {
+	zone, err := s.Get(ctx, zoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range zone.Records {
+		if zone.Records[i].Id == recordId {
+			return &zone.Records[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("DNS record %d in zone %d: %w", recordId, zoneId, common.ErrNotFound)
+}
+
+// FindRecord returns the first DNS record with the given name and type,
+// or an error matching common.ErrNotFound if there is none
+func (s *DNSZoneService) FindRecord(ctx context.Context, zoneId int64, name string, recordType DNSRecordType) (*DNSRecord, error) {
+	records, err := s.ListRecords(ctx, zoneId, &DNSRecordFilter{Name: &name, Types: []DNSRecordType{recordType}})
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s record %q in zone %d: %w", recordType, name, zoneId, common.ErrNotFound)
+	}
+
+	return &records[0], nil
+}
+
+// FindZoneForDomain returns the DNS zone that owns a domain name, such as the zone "example.com" for
+// "api.example.com". When zones exist for several parent domains the longest match wins.
+// An error matching common.ErrNotFound is returned when no zone owns the domain.
+func (s *DNSZoneService) FindZoneForDomain(ctx context.Context, domain string) (*DNSZone, error) {
+	name := common.NormalizeDomain(domain)
+
+	zones, err := s.ListAll(ctx, common.MaxPerPage, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var best *DNSZone
+	bestLength := 0
+	for i := range zones {
+		zoneDomain := common.NormalizeDomain(zones[i].Domain)
+		if name != zoneDomain && !strings.HasSuffix(name, "."+zoneDomain) {
+			continue
+		}
+		if best == nil || len(zoneDomain) > bestLength {
+			best = &zones[i]
+			bestLength = len(zoneDomain)
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("DNS zone for %q: %w", domain, common.ErrNotFound)
+	}
+
+	return best, nil
+}
+
+// RecordName returns the name of a domain relative to the zone, with an empty name for the apex.
+// The second result is false when the domain is not part of the zone.
+func (z *DNSZone) RecordName(domain string) (string, bool) {
+	name := common.NormalizeDomain(domain)
+	zoneDomain := common.NormalizeDomain(z.Domain)
+
+	if name == zoneDomain {
+		return "", true
+	}
+	if strings.HasSuffix(name, "."+zoneDomain) {
+		return strings.TrimSuffix(name, "."+zoneDomain), true
+	}
+	return "", false
+}
+
+// sameRecordName compares record names case-insensitively, treating "@" and "" as the apex
+func sameRecordName(a, b string) bool {
+	if isApex(a) || isApex(b) {
+		return isApex(a) && isApex(b)
+	}
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
@@ -0,0 +1,332 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/venom90/bunnynet-go/common"
+)
+
+// defaultSyncConcurrency is the number of concurrent requests used by Sync when none is set
+const defaultSyncConcurrency = 4
+
+// SyncAction represents the kind of change made to a DNS record by Sync
+type SyncAction string
+
+const (
+	// SyncActionAdd adds a record that is missing from the zone
+	SyncActionAdd SyncAction = "add"
+	// SyncActionUpdate updates the settings of an existing record
+	SyncActionUpdate SyncAction = "update"
+	// SyncActionDelete deletes a record that is not in the desired state
+	SyncActionDelete SyncAction = "delete"
+)
+
+// SyncOptions represents the options for synchronizing the records of a DNS zone with
+// DNSZoneService.Sync or a Pull Zone list with PullZoneService.SetBlockedIPs and its siblings
+type SyncOptions struct {
+	// DryRun computes the changes without applying them
+	DryRun bool
+
+	// NoDelete keeps records or list entries that are not in the desired state instead of deleting them
+	NoDelete bool
+
+	// Concurrency is the maximum number of concurrent requests, defaults to 4
+	Concurrency int
+}
+
+// RecordChange represents a single record change computed by Sync
+type RecordChange struct {
+	// Action is the kind of change
+	Action SyncAction
+
+	// Key is the (Name, Type, Value) identity of the record
+	Key string
+
+	// Current is the live record, nil for additions
+	Current *DNSRecord
+
+	// Desired is the desired record, nil for deletions
+	Desired *AddDNSRecordOptions
+
+	// Fields is the list of changed fields for updates
+	Fields []string
+
+	// Record is the record returned by the API for applied additions
+	Record *DNSRecord
+
+	// Err is the error returned when applying the change, nil on success or in a dry run
+	Err error
+
+	update UpdateDNSRecordOptions
+}
+
+// SyncReport represents the result of synchronizing the records of a DNS zone
+type SyncReport struct {
+	// ZoneId is the ID of the synchronized DNS zone
+	ZoneId int64
+
+	// DryRun indicates that the changes were computed but not applied
+	DryRun bool
+
+	// Changes is the list of changes in the order they are applied: deletions, updates, then additions
+	Changes []RecordChange
+
+	// Unchanged is the number of records that already match the desired state
+	Unchanged int
+
+	// Kept is the list of records that are not in the desired state but were kept because of NoDelete
+	Kept []DNSRecord
+}
+
+// Counts returns the number of additions, updates and deletions in the report
+func (r *SyncReport) Counts() (added, updated, deleted int) {
+	for _, change := range r.Changes {
+		switch change.Action {
+		case SyncActionAdd:
+			added++
+		case SyncActionUpdate:
+			updated++
+		case SyncActionDelete:
+			deleted++
+		}
+	}
+	return added, updated, deleted
+}
+
+// Failed returns the changes that could not be applied
+func (r *SyncReport) Failed() []RecordChange {
+	var failed []RecordChange
+	for _, change := range r.Changes {
+		if change.Err != nil {
+			failed = append(failed, change)
+		}
+	}
+	return failed
+}
+
+// Sync reconciles the records of a DNS zone with the desired records. Records are matched by their
+// (Name, Type, Value) identity, with "@" and "" both naming the apex and host name values compared
+// case-insensitively. PullZone and Script records are matched by (Name, Type) only, so pointing one
+// at another target updates its PullZoneId or ScriptId. Missing records are added, records with
+// different settings are updated and records that are not desired are deleted unless NoDelete is set.
+// A Ttl of 0 in a desired record leaves the TTL of an existing record unchanged.
+//
+// The report is returned even when some changes fail; the error then joins the errors of all failed changes.
+func (s *DNSZoneService) Sync(ctx context.Context, zoneId int64, desired []AddDNSRecordOptions, opts *SyncOptions) (*SyncReport, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+
+	wanted := make(map[string]*AddDNSRecordOptions, len(desired))
+	for i := range desired {
+		if err := desired[i].Validate(); err != nil {
+			return nil, fmt.Errorf("desired record %s: %w", syncRecordKey(desired[i].Name, desired[i].Type, desired[i].Value), err)
+		}
+		key := syncRecordKey(desired[i].Name, desired[i].Type, desired[i].Value)
+		if wanted[key] != nil {
+			return nil, fmt.Errorf("desired record %s is declared more than once", key)
+		}
+		wanted[key] = &desired[i]
+	}
+
+	zone, err := s.Get(ctx, zoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SyncReport{ZoneId: zoneId, DryRun: opts.DryRun}
+
+	var deletes, updates, adds []RecordChange
+	seen := make(map[string]bool, len(zone.Records))
+	for i := range zone.Records {
+		current := &zone.Records[i]
+		key := syncRecordKey(current.Name, current.Type, current.Value)
+		target := wanted[key]
+
+		if target == nil || seen[key] {
+			// Duplicate live records are removed so that exactly one record remains per identity
+			if opts.NoDelete {
+				report.Kept = append(report.Kept, *current)
+				continue
+			}
+			deletes = append(deletes, RecordChange{Action: SyncActionDelete, Key: key, Current: current})
+			continue
+		}
+		seen[key] = true
+
+		update, fields := diffDNSRecord(current, target)
+		if len(fields) == 0 {
+			report.Unchanged++
+			continue
+		}
+		updates = append(updates, RecordChange{
+			Action:  SyncActionUpdate,
+			Key:     key,
+			Current: current,
+			Desired: target,
+			Fields:  fields,
+			update:  update,
+		})
+	}
+
+	for key, target := range wanted {
+		if !seen[key] {
+			adds = append(adds, RecordChange{Action: SyncActionAdd, Key: key, Desired: target})
+		}
+	}
+
+	for _, changes := range [][]RecordChange{deletes, updates, adds} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	}
+
+	if !opts.DryRun {
+		concurrency := opts.Concurrency
+		if concurrency <= 0 {
+			concurrency = defaultSyncConcurrency
+		}
+
+		// Deletions run first so that conflicting records, such as a CNAME replacing an A record, can be added
+		for _, changes := range [][]RecordChange{deletes, updates, adds} {
+			s.applySyncChanges(ctx, zoneId, changes, concurrency)
+		}
+	}
+
+	report.Changes = append(append(deletes, updates...), adds...)
+
+	var errs []error
+	for _, change := range report.Failed() {
+		errs = append(errs, fmt.Errorf("%s %s: %w", change.Action, change.Key, change.Err))
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// applySyncChanges applies a group of changes with bounded concurrency, recording the result on each change
+func (s *DNSZoneService) applySyncChanges(ctx context.Context, zoneId int64, changes []RecordChange, concurrency int) {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := range changes {
+		change := &changes[i]
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			change.Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			switch change.Action {
+			case SyncActionDelete:
+				change.Err = s.DeleteRecord(ctx, zoneId, change.Current.Id)
+			case SyncActionUpdate:
+				change.Err = s.UpdateRecord(ctx, zoneId, change.Current.Id, change.update)
+			case SyncActionAdd:
+				change.Record, change.Err = s.AddRecord(ctx, zoneId, *change.Desired)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// syncRecordKey returns the (Name, Type, Value) identity of a record
+func syncRecordKey(name string, recordType DNSRecordType, value string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = "@"
+	}
+
+	switch recordType {
+	case DNSRecordTypePullZone, DNSRecordTypeScript:
+		value = ""
+	case DNSRecordTypeCNAME, DNSRecordTypeMX, DNSRecordTypeNS, DNSRecordTypePTR, DNSRecordTypeSRV:
+		value = common.NormalizeDomain(value)
+	}
+
+	return fmt.Sprintf("%s %d %s", name, recordType, value)
+}
+
+// diffDNSRecord returns the update options and names of the fields that differ between a live and a desired record
+func diffDNSRecord(current *DNSRecord, desired *AddDNSRecordOptions) (UpdateDNSRecordOptions, []string) {
+	update := UpdateDNSRecordOptions{Id: current.Id}
+	var fields []string
+
+	if desired.Ttl != 0 && current.Ttl != desired.Ttl {
+		update.Ttl = &desired.Ttl
+		fields = append(fields, "Ttl")
+	}
+	if current.Priority != desired.Priority {
+		update.Priority = &desired.Priority
+		fields = append(fields, "Priority")
+	}
+	if current.Weight != desired.Weight {
+		update.Weight = &desired.Weight
+		fields = append(fields, "Weight")
+	}
+	if current.Port != desired.Port {
+		update.Port = &desired.Port
+		fields = append(fields, "Port")
+	}
+	if current.Flags != desired.Flags {
+		update.Flags = &desired.Flags
+		fields = append(fields, "Flags")
+	}
+	if current.Tag != desired.Tag {
+		update.Tag = &desired.Tag
+		fields = append(fields, "Tag")
+	}
+	if current.Accelerated != desired.Accelerated {
+		update.Accelerated = &desired.Accelerated
+		fields = append(fields, "Accelerated")
+	}
+	if current.MonitorType != desired.MonitorType {
+		update.MonitorType = &desired.MonitorType
+		fields = append(fields, "MonitorType")
+	}
+	if current.LatencyZone != desired.LatencyZone {
+		update.LatencyZone = &desired.LatencyZone
+		fields = append(fields, "LatencyZone")
+	}
+	if current.SmartRoutingType != desired.SmartRoutingType {
+		update.SmartRoutingType = &desired.SmartRoutingType
+		fields = append(fields, "SmartRoutingType")
+	}
+	if current.GeolocationLatitude != desired.GeolocationLatitude {
+		update.GeolocationLatitude = &desired.GeolocationLatitude
+		fields = append(fields, "GeolocationLatitude")
+	}
+	if current.GeolocationLongitude != desired.GeolocationLongitude {
+		update.GeolocationLongitude = &desired.GeolocationLongitude
+		fields = append(fields, "GeolocationLongitude")
+	}
+	// PullZone and Script records are matched without their value, so the target is compared here
+	if desired.PullZoneId != 0 && current.PullZoneId != desired.PullZoneId {
+		update.PullZoneId = &desired.PullZoneId
+		fields = append(fields, "PullZoneId")
+	}
+	if desired.ScriptId != 0 && current.ScriptId != desired.ScriptId {
+		update.ScriptId = &desired.ScriptId
+		fields = append(fields, "ScriptId")
+	}
+	if current.Disabled != desired.Disabled {
+		update.Disabled = &desired.Disabled
+		fields = append(fields, "Disabled")
+	}
+	if current.Comment != desired.Comment {
+		update.Comment = &desired.Comment
+		fields = append(fields, "Comment")
+	}
+
+	return update, fields
+}
@@ -0,0 +1,249 @@
+package resources
+
+import (
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/common"
+)
+
+// EdgeRuleActionType represents the action performed by an edge rule
+type EdgeRuleActionType int
+
+const (
+	// EdgeRuleActionTypeForceSSL redirects HTTP requests to HTTPS
+	EdgeRuleActionTypeForceSSL EdgeRuleActionType = 0
+	// EdgeRuleActionTypeRedirect redirects the request to the URL in ActionParameter1
+	EdgeRuleActionTypeRedirect EdgeRuleActionType = 1
+	// EdgeRuleActionTypeOriginUrl changes the origin URL to ActionParameter1
+	EdgeRuleActionTypeOriginUrl EdgeRuleActionType = 2
+	// EdgeRuleActionTypeOverrideCacheTime overrides the edge cache time with ActionParameter1 seconds
+	EdgeRuleActionTypeOverrideCacheTime EdgeRuleActionType = 3
+	// EdgeRuleActionTypeBlockRequest blocks the request
+	EdgeRuleActionTypeBlockRequest EdgeRuleActionType = 4
+	// EdgeRuleActionTypeSetResponseHeader sets the response header ActionParameter1 to ActionParameter2
+	EdgeRuleActionTypeSetResponseHeader EdgeRuleActionType = 5
+	// EdgeRuleActionTypeSetRequestHeader sets the request header ActionParameter1 to ActionParameter2
+	EdgeRuleActionTypeSetRequestHeader EdgeRuleActionType = 6
+	// EdgeRuleActionTypeForceDownload forces the response to be downloaded
+	EdgeRuleActionTypeForceDownload EdgeRuleActionType = 7
+	// EdgeRuleActionTypeDisableTokenAuthentication disables token authentication
+	EdgeRuleActionTypeDisableTokenAuthentication EdgeRuleActionType = 8
+	// EdgeRuleActionTypeEnableTokenAuthentication enables token authentication
+	EdgeRuleActionTypeEnableTokenAuthentication EdgeRuleActionType = 9
+	// EdgeRuleActionTypeOverrideCacheTimePublic overrides the browser cache time with ActionParameter1 seconds
+	EdgeRuleActionTypeOverrideCacheTimePublic EdgeRuleActionType = 10
+	// EdgeRuleActionTypeIgnoreQueryString ignores the query string when caching
+	EdgeRuleActionTypeIgnoreQueryString EdgeRuleActionType = 11
+	// EdgeRuleActionTypeDisableOptimizer disables Bunny Optimizer
+	EdgeRuleActionTypeDisableOptimizer EdgeRuleActionType = 12
+	// EdgeRuleActionTypeForceCompression forces compression of the response
+	EdgeRuleActionTypeForceCompression EdgeRuleActionType = 13
+	// EdgeRuleActionTypeSetStatusCode sets the response status code to ActionParameter1
+	EdgeRuleActionTypeSetStatusCode EdgeRuleActionType = 14
+	// EdgeRuleActionTypeBypassPermaCache bypasses Perma-Cache
+	EdgeRuleActionTypeBypassPermaCache EdgeRuleActionType = 15
+	// EdgeRuleActionTypeOverrideBrowserCacheTime overrides the browser cache time with ActionParameter1 seconds
+	EdgeRuleActionTypeOverrideBrowserCacheTime EdgeRuleActionType = 16
+	// EdgeRuleActionTypeOriginStorage serves the request from the storage zone in ActionParameter1
+	EdgeRuleActionTypeOriginStorage EdgeRuleActionType = 17
+	// EdgeRuleActionTypeSetNetworkRateLimit limits the download speed to ActionParameter1 kB/s
+	EdgeRuleActionTypeSetNetworkRateLimit EdgeRuleActionType = 18
+	// EdgeRuleActionTypeSetConnectionLimit limits the connections per IP to ActionParameter1
+	EdgeRuleActionTypeSetConnectionLimit EdgeRuleActionType = 19
+	// EdgeRuleActionTypeSetRequestsPerSecondLimit limits the requests per second per IP to ActionParameter1
+	EdgeRuleActionTypeSetRequestsPerSecondLimit EdgeRuleActionType = 20
+)
+
+// EdgeRuleTriggerType represents what an edge rule trigger matches against
+type EdgeRuleTriggerType int
+
+const (
+	// EdgeRuleTriggerTypeUrl matches the request URL
+	EdgeRuleTriggerTypeUrl EdgeRuleTriggerType = 0
+	// EdgeRuleTriggerTypeRequestHeader matches the request header named in Parameter1
+	EdgeRuleTriggerTypeRequestHeader EdgeRuleTriggerType = 1
+	// EdgeRuleTriggerTypeResponseHeader matches the response header named in Parameter1
+	EdgeRuleTriggerTypeResponseHeader EdgeRuleTriggerType = 2
+	// EdgeRuleTriggerTypeUrlExtension matches the file extension of the request URL
+	EdgeRuleTriggerTypeUrlExtension EdgeRuleTriggerType = 3
+	// EdgeRuleTriggerTypeCountryCode matches the two-letter country code of the client
+	EdgeRuleTriggerTypeCountryCode EdgeRuleTriggerType = 4
+	// EdgeRuleTriggerTypeRemoteIP matches the IP address of the client
+	EdgeRuleTriggerTypeRemoteIP EdgeRuleTriggerType = 5
+	// EdgeRuleTriggerTypeUrlQueryString matches the query string parameter named in Parameter1
+	EdgeRuleTriggerTypeUrlQueryString EdgeRuleTriggerType = 6
+	// EdgeRuleTriggerTypeRandomChance matches a percentage of requests given in Parameter1
+	EdgeRuleTriggerTypeRandomChance EdgeRuleTriggerType = 7
+	// EdgeRuleTriggerTypeStatusCode matches the response status code
+	EdgeRuleTriggerTypeStatusCode EdgeRuleTriggerType = 8
+	// EdgeRuleTriggerTypeRequestMethod matches the request method
+	EdgeRuleTriggerTypeRequestMethod EdgeRuleTriggerType = 9
+	// EdgeRuleTriggerTypeCookieValue matches the cookie named in Parameter1
+	EdgeRuleTriggerTypeCookieValue EdgeRuleTriggerType = 10
+	// EdgeRuleTriggerTypeCountryStateCode matches the state code of the client
+	EdgeRuleTriggerTypeCountryStateCode EdgeRuleTriggerType = 11
+	// EdgeRuleTriggerTypeOriginRetryAttemptCount matches the number of origin retry attempts
+	EdgeRuleTriggerTypeOriginRetryAttemptCount EdgeRuleTriggerType = 12
+	// EdgeRuleTriggerTypeOriginConnectionError matches origin connection errors
+	EdgeRuleTriggerTypeOriginConnectionError EdgeRuleTriggerType = 13
+)
+
+// PatternMatchingType represents how the patterns of a trigger are combined
+type PatternMatchingType int
+
+const (
+	// PatternMatchingTypeMatchAny matches when any pattern matches
+	PatternMatchingTypeMatchAny PatternMatchingType = 0
+	// PatternMatchingTypeMatchAll matches when all patterns match
+	PatternMatchingTypeMatchAll PatternMatchingType = 1
+	// PatternMatchingTypeMatchNone matches when no pattern matches
+	PatternMatchingTypeMatchNone PatternMatchingType = 2
+)
+
+// TriggerMatchingType represents how the triggers of an edge rule are combined
+type TriggerMatchingType int
+
+const (
+	// TriggerMatchingTypeMatchAny runs the rule when any trigger matches
+	TriggerMatchingTypeMatchAny TriggerMatchingType = 0
+	// TriggerMatchingTypeMatchAll runs the rule when all triggers match
+	TriggerMatchingTypeMatchAll TriggerMatchingType = 1
+	// TriggerMatchingTypeMatchNone runs the rule when no trigger matches
+	TriggerMatchingTypeMatchNone TriggerMatchingType = 2
+)
+
+// edgeRuleVariablePattern matches Bunny dynamic variables such as %{Url.Path} in edge rule parameters
+var edgeRuleVariablePattern = regexp.MustCompile(`%\{[^}]*\}`)
+
+// Options returns the options that add or update the edge rule, keeping its Guid
+func (r EdgeRule) Options() AddOrUpdateEdgeRuleOptions {
+	return AddOrUpdateEdgeRuleOptions{
+		Guid:                r.Guid,
+		ActionType:          r.ActionType,
+		ActionParameter1:    r.ActionParameter1,
+		ActionParameter2:    r.ActionParameter2,
+		Triggers:            r.Triggers,
+		TriggerMatchingType: r.TriggerMatchingType,
+		Description:         r.Description,
+		Enabled:             r.Enabled,
+	}
+}
+
+// Validate checks the edge rule options for errors the API would reject. Action and trigger types
+// added to the API after this package are passed through without checking their parameters.
+func (o AddOrUpdateEdgeRuleOptions) Validate() error {
+	errs := &common.ValidationError{}
+
+	if o.ActionType < 0 {
+		errs.Add("ActionType", "unknown action type %d", o.ActionType)
+	}
+
+	switch o.ActionType {
+	case EdgeRuleActionTypeRedirect, EdgeRuleActionTypeOriginUrl:
+		if o.ActionParameter1 == "" {
+			errs.Add("ActionParameter1", "a URL is required")
+		} else if u, err := url.Parse(edgeRuleVariablePattern.ReplaceAllString(o.ActionParameter1, "x")); err != nil || u.Scheme == "" || u.Host == "" {
+			errs.Add("ActionParameter1", "%q is not an absolute URL", o.ActionParameter1)
+		}
+		if o.ActionType == EdgeRuleActionTypeRedirect && o.ActionParameter2 != "" {
+			switch o.ActionParameter2 {
+			case "301", "302", "307", "308":
+			default:
+				errs.Add("ActionParameter2", "redirect status code must be 301, 302, 307 or 308")
+			}
+		}
+	case EdgeRuleActionTypeSetResponseHeader, EdgeRuleActionTypeSetRequestHeader:
+		if strings.TrimSpace(o.ActionParameter1) == "" {
+			errs.Add("ActionParameter1", "a header name is required")
+		}
+	case EdgeRuleActionTypeOverrideCacheTime, EdgeRuleActionTypeOverrideCacheTimePublic,
+		EdgeRuleActionTypeOverrideBrowserCacheTime, EdgeRuleActionTypeSetNetworkRateLimit,
+		EdgeRuleActionTypeSetConnectionLimit, EdgeRuleActionTypeSetRequestsPerSecondLimit:
+		if n, err := strconv.ParseInt(o.ActionParameter1, 10, 64); err != nil || n < 0 {
+			errs.Add("ActionParameter1", "must be a non-negative integer")
+		}
+	case EdgeRuleActionTypeSetStatusCode:
+		if n, err := strconv.Atoi(o.ActionParameter1); err != nil || n < 100 || n > 599 {
+			errs.Add("ActionParameter1", "must be an HTTP status code")
+		}
+	case EdgeRuleActionTypeOriginStorage:
+		if o.ActionParameter1 == "" {
+			errs.Add("ActionParameter1", "a storage zone is required")
+		}
+	}
+
+	if o.TriggerMatchingType < 0 || o.TriggerMatchingType > TriggerMatchingTypeMatchNone {
+		errs.Add("TriggerMatchingType", "unknown trigger matching type %d", o.TriggerMatchingType)
+	}
+
+	if len(o.Triggers) == 0 {
+		errs.Add("Triggers", "at least one trigger is required")
+	}
+	for i, trigger := range o.Triggers {
+		validateEdgeRuleTrigger(errs, "Triggers["+strconv.Itoa(i)+"]", trigger)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// validateEdgeRuleTrigger checks a single edge rule trigger
+func validateEdgeRuleTrigger(errs *common.ValidationError, field string, trigger EdgeRuleTrigger) {
+	if trigger.Type < 0 {
+		errs.Add(field+".Type", "unknown trigger type %d", trigger.Type)
+	}
+	if trigger.PatternMatchingType < 0 || trigger.PatternMatchingType > PatternMatchingTypeMatchNone {
+		errs.Add(field+".PatternMatchingType", "unknown pattern matching type %d", trigger.PatternMatchingType)
+	}
+
+	switch trigger.Type {
+	case EdgeRuleTriggerTypeRequestHeader, EdgeRuleTriggerTypeResponseHeader,
+		EdgeRuleTriggerTypeUrlQueryString, EdgeRuleTriggerTypeCookieValue:
+		if trigger.Parameter1 == "" {
+			errs.Add(field+".Parameter1", "a name is required for this trigger type")
+		}
+	case EdgeRuleTriggerTypeRandomChance:
+		if n, err := strconv.Atoi(trigger.Parameter1); err != nil || n < 0 || n > 100 {
+			errs.Add(field+".Parameter1", "must be a percentage between 0 and 100")
+		}
+		return
+	case EdgeRuleTriggerTypeOriginConnectionError:
+		return
+	}
+
+	if len(trigger.PatternMatches) == 0 {
+		errs.Add(field+".PatternMatches", "at least one pattern is required")
+	}
+
+	for _, pattern := range trigger.PatternMatches {
+		switch trigger.Type {
+		case EdgeRuleTriggerTypeCountryCode:
+			if len(pattern) != 2 {
+				errs.Add(field+".PatternMatches", "%q is not a two-letter country code", pattern)
+			}
+		case EdgeRuleTriggerTypeRemoteIP:
+			if !validIPPattern(pattern) {
+				errs.Add(field+".PatternMatches", "%q is not an IP address or CIDR range", pattern)
+			}
+		case EdgeRuleTriggerTypeStatusCode:
+			if n, err := strconv.Atoi(pattern); err != nil || n < 100 || n > 599 {
+				errs.Add(field+".PatternMatches", "%q is not an HTTP status code", pattern)
+			}
+		}
+	}
+}
+
+// validIPPattern reports whether the pattern is an IP address, a CIDR range or a wildcard pattern
+func validIPPattern(pattern string) bool {
+	if strings.Contains(pattern, "*") {
+		return true
+	}
+	if _, err := netip.ParseAddr(pattern); err == nil {
+		return true
+	}
+	_, err := netip.ParsePrefix(pattern)
+	return err == nil
+}
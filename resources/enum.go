@@ -0,0 +1,441 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// enum is implemented by the integer enum types of the API
+type enum interface {
+	~int
+}
+
+// formatEnum returns the name of an enum value, or TypeName(n) for unknown values
+func formatEnum[T enum](typeName string, names []string, v T) string {
+	if v >= 0 && int(v) < len(names) && names[v] != "" {
+		return names[v]
+	}
+	return fmt.Sprintf("%s(%d)", typeName, int(v))
+}
+
+// parseEnum parses an enum value from its case-insensitive name or its number
+func parseEnum[T enum](typeName string, names []string, s string) (T, error) {
+	s = strings.TrimSpace(s)
+	for i, name := range names {
+		if name != "" && strings.EqualFold(name, s) {
+			return T(i), nil
+		}
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n < len(names) {
+		return T(n), nil
+	}
+	return 0, fmt.Errorf("invalid %s %q", typeName, s)
+}
+
+// unmarshalEnumJSON decodes an enum from a JSON number, or from a JSON string holding its name
+func unmarshalEnumJSON[T enum](typeName string, names []string, data []byte, v *T) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		s, err := strconv.Unquote(string(data))
+		if err != nil {
+			return fmt.Errorf("invalid %s %s", typeName, data)
+		}
+		parsed, err := parseEnum[T](typeName, names, s)
+		if err != nil {
+			return err
+		}
+		*v = parsed
+		return nil
+	}
+
+	// Unknown numbers are kept so that values added to the API later still decode
+	n, err := strconv.Atoi(string(data))
+	if err != nil {
+		return fmt.Errorf("invalid %s %s", typeName, data)
+	}
+	*v = T(n)
+	return nil
+}
+
+// dnsRecordTypeNames contains the names of the DNS record types, indexed by value
+var dnsRecordTypeNames = []string{"A", "AAAA", "CNAME", "TXT", "MX", "Redirect", "Flatten", "PullZone", "SRV", "CAA", "PTR", "Script", "NS"}
+
+// String returns the name of the DNS record type, such as "MX"
+func (t DNSRecordType) String() string {
+	return formatEnum("DNSRecordType", dnsRecordTypeNames, t)
+}
+
+// ParseDNSRecordType parses a DNS record type from its case-insensitive name, such as "MX", or its number
+func ParseDNSRecordType(s string) (DNSRecordType, error) {
+	return parseEnum[DNSRecordType]("DNSRecordType", dnsRecordTypeNames, s)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (t DNSRecordType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (t *DNSRecordType) UnmarshalText(text []byte) error {
+	parsed, err := ParseDNSRecordType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON encodes the DNS record type as a number, as expected by the API
+func (t DNSRecordType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(t))), nil
+}
+
+// UnmarshalJSON decodes the DNS record type from a number or a name
+func (t *DNSRecordType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON("DNSRecordType", dnsRecordTypeNames, data, t)
+}
+
+// monitorStatusNames contains the names of the monitor statuses, indexed by value
+var monitorStatusNames = []string{"Unknown", "Online", "Offline"}
+
+// String returns the name of the monitor status
+func (s MonitorStatus) String() string {
+	return formatEnum("MonitorStatus", monitorStatusNames, s)
+}
+
+// ParseMonitorStatus parses a monitor status from its case-insensitive name or its number
+func ParseMonitorStatus(s string) (MonitorStatus, error) {
+	return parseEnum[MonitorStatus]("MonitorStatus", monitorStatusNames, s)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (s MonitorStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (s *MonitorStatus) UnmarshalText(text []byte) error {
+	parsed, err := ParseMonitorStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// MarshalJSON encodes the monitor status as a number, as expected by the API
+func (s MonitorStatus) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(s))), nil
+}
+
+// UnmarshalJSON decodes the monitor status from a number or a name
+func (s *MonitorStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON("MonitorStatus", monitorStatusNames, data, s)
+}
+
+// monitorTypeNames contains the names of the monitor types, indexed by value
+var monitorTypeNames = []string{"None", "Ping", "Http", "Monitor"}
+
+// String returns the name of the monitor type
+func (t MonitorType) String() string {
+	return formatEnum("MonitorType", monitorTypeNames, t)
+}
+
+// ParseMonitorType parses a monitor type from its case-insensitive name or its number
+func ParseMonitorType(s string) (MonitorType, error) {
+	return parseEnum[MonitorType]("MonitorType", monitorTypeNames, s)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (t MonitorType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (t *MonitorType) UnmarshalText(text []byte) error {
+	parsed, err := ParseMonitorType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON encodes the monitor type as a number, as expected by the API
+func (t MonitorType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(t))), nil
+}
+
+// UnmarshalJSON decodes the monitor type from a number or a name
+func (t *MonitorType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON("MonitorType", monitorTypeNames, data, t)
+}
+
+// smartRoutingTypeNames contains the names of the smart routing types, indexed by value
+var smartRoutingTypeNames = []string{"None", "Latency", "Geolocation"}
+
+// String returns the name of the smart routing type
+func (t SmartRoutingType) String() string {
+	return formatEnum("SmartRoutingType", smartRoutingTypeNames, t)
+}
+
+// ParseSmartRoutingType parses a smart routing type from its case-insensitive name or its number
+func ParseSmartRoutingType(s string) (SmartRoutingType, error) {
+	return parseEnum[SmartRoutingType]("SmartRoutingType", smartRoutingTypeNames, s)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (t SmartRoutingType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (t *SmartRoutingType) UnmarshalText(text []byte) error {
+	parsed, err := ParseSmartRoutingType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON encodes the smart routing type as a number, as expected by the API
+func (t SmartRoutingType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(t))), nil
+}
+
+// UnmarshalJSON decodes the smart routing type from a number or a name
+func (t *SmartRoutingType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON("SmartRoutingType", smartRoutingTypeNames, data, t)
+}
+
+// logAnonymizationTypeNames contains the names of the log anonymization types, indexed by value
+var logAnonymizationTypeNames = []string{"OneDigit", "Drop"}
+
+// String returns the name of the log anonymization type
+func (t LogAnonymizationType) String() string {
+	return formatEnum("LogAnonymizationType", logAnonymizationTypeNames, t)
+}
+
+// ParseLogAnonymizationType parses a log anonymization type from its case-insensitive name or its number
+func ParseLogAnonymizationType(s string) (LogAnonymizationType, error) {
+	return parseEnum[LogAnonymizationType]("LogAnonymizationType", logAnonymizationTypeNames, s)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (t LogAnonymizationType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (t *LogAnonymizationType) UnmarshalText(text []byte) error {
+	parsed, err := ParseLogAnonymizationType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON encodes the log anonymization type as a number, as expected by the API
+func (t LogAnonymizationType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(t))), nil
+}
+
+// UnmarshalJSON decodes the log anonymization type from a number or a name
+func (t *LogAnonymizationType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON("LogAnonymizationType", logAnonymizationTypeNames, data, t)
+}
+
+// pullZoneTypeNames contains the names of the pull zone types, indexed by value
+var pullZoneTypeNames = []string{"Premium", "Volume"}
+
+// String returns the name of the pull zone type
+func (t PullZoneType) String() string {
+	return formatEnum("PullZoneType", pullZoneTypeNames, t)
+}
+
+// ParsePullZoneType parses a pull zone type from its case-insensitive name or its number
+func ParsePullZoneType(s string) (PullZoneType, error) {
+	return parseEnum[PullZoneType]("PullZoneType", pullZoneTypeNames, s)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (t PullZoneType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (t *PullZoneType) UnmarshalText(text []byte) error {
+	parsed, err := ParsePullZoneType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON encodes the pull zone type as a number, as expected by the API
+func (t PullZoneType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(t))), nil
+}
+
+// UnmarshalJSON decodes the pull zone type from a number or a name
+func (t *PullZoneType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON("PullZoneType", pullZoneTypeNames, data, t)
+}
+
+// originTypeNames contains the names of the origin types, indexed by value
+var originTypeNames = []string{"OriginUrl", "DnsAccelerate", "StorageZone", "LoadBalancer", "EdgeScript", "MagicContainers", "PushZone"}
+
+// String returns the name of the origin type
+func (t OriginType) String() string {
+	return formatEnum("OriginType", originTypeNames, t)
+}
+
+// ParseOriginType parses a origin type from its case-insensitive name or its number
+func ParseOriginType(s string) (OriginType, error) {
+	return parseEnum[OriginType]("OriginType", originTypeNames, s)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (t OriginType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (t *OriginType) UnmarshalText(text []byte) error {
+	parsed, err := ParseOriginType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON encodes the origin type as a number, as expected by the API
+func (t OriginType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(t))), nil
+}
+
+// UnmarshalJSON decodes the origin type from a number or a name
+func (t *OriginType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON("OriginType", originTypeNames, data, t)
+}
+
+// logFormatNames contains the names of the log formats, indexed by value
+var logFormatNames = []string{"Plain", "JSON"}
+
+// String returns the name of the log format
+func (f LogFormat) String() string {
+	return formatEnum("LogFormat", logFormatNames, f)
+}
+
+// ParseLogFormat parses a log format from its case-insensitive name or its number
+func ParseLogFormat(s string) (LogFormat, error) {
+	return parseEnum[LogFormat]("LogFormat", logFormatNames, s)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (f LogFormat) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (f *LogFormat) UnmarshalText(text []byte) error {
+	parsed, err := ParseLogFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*f = parsed
+	return nil
+}
+
+// MarshalJSON encodes the log format as a number, as expected by the API
+func (f LogFormat) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(f))), nil
+}
+
+// UnmarshalJSON decodes the log format from a number or a name
+func (f *LogFormat) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON("LogFormat", logFormatNames, data, f)
+}
+
+// logForwardingFormatNames contains the names of the log forwarding formats, indexed by value
+var logForwardingFormatNames = []string{"Plain", "JSON"}
+
+// String returns the name of the log forwarding format
+func (f LogForwardingFormat) String() string {
+	return formatEnum("LogForwardingFormat", logForwardingFormatNames, f)
+}
+
+// ParseLogForwardingFormat parses a log forwarding format from its case-insensitive name or its number
+func ParseLogForwardingFormat(s string) (LogForwardingFormat, error) {
+	return parseEnum[LogForwardingFormat]("LogForwardingFormat", logForwardingFormatNames, s)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (f LogForwardingFormat) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (f *LogForwardingFormat) UnmarshalText(text []byte) error {
+	parsed, err := ParseLogForwardingFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*f = parsed
+	return nil
+}
+
+// MarshalJSON encodes the log forwarding format as a number, as expected by the API
+func (f LogForwardingFormat) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(f))), nil
+}
+
+// UnmarshalJSON decodes the log forwarding format from a number or a name
+func (f *LogForwardingFormat) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON("LogForwardingFormat", logForwardingFormatNames, data, f)
+}
+
+// logForwardingProtocolNames contains the names of the log forwarding protocols, indexed by value
+var logForwardingProtocolNames = []string{"UDP", "TCP", "TCPEncrypted", "DataDog"}
+
+// String returns the name of the log forwarding protocol
+func (p LogForwardingProtocol) String() string {
+	return formatEnum("LogForwardingProtocol", logForwardingProtocolNames, p)
+}
+
+// ParseLogForwardingProtocol parses a log forwarding protocol from its case-insensitive name or its number
+func ParseLogForwardingProtocol(s string) (LogForwardingProtocol, error) {
+	return parseEnum[LogForwardingProtocol]("LogForwardingProtocol", logForwardingProtocolNames, s)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (p LogForwardingProtocol) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (p *LogForwardingProtocol) UnmarshalText(text []byte) error {
+	parsed, err := ParseLogForwardingProtocol(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// MarshalJSON encodes the log forwarding protocol as a number, as expected by the API
+func (p LogForwardingProtocol) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(p))), nil
+}
+
+// UnmarshalJSON decodes the log forwarding protocol from a number or a name
+func (p *LogForwardingProtocol) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON("LogForwardingProtocol", logForwardingProtocolNames, data, p)
+}
@@ -4,12 +4,77 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"reflect"
 	"time"
 
 	"github.com/venom90/bunnynet-go/common"
 	"github.com/venom90/bunnynet-go/internal"
 )
 
+// PullZoneType represents the pricing tier of a Pull Zone
+type PullZoneType int
+
+const (
+	// PullZoneTypePremium serves from the premium network of high performance edge servers
+	PullZoneTypePremium PullZoneType = 0
+	// PullZoneTypeVolume serves from the lower cost volume network, suited for large files
+	PullZoneTypeVolume PullZoneType = 1
+)
+
+// OriginType represents where a Pull Zone fetches its content from
+type OriginType int
+
+const (
+	// OriginTypeURL fetches content from the origin URL
+	OriginTypeURL OriginType = 0
+	// OriginTypeDNSAccelerate accelerates a website whose DNS is hosted by Bunny DNS
+	OriginTypeDNSAccelerate OriginType = 1
+	// OriginTypeStorageZone fetches content from the storage zone in StorageZoneId
+	OriginTypeStorageZone OriginType = 2
+	// OriginTypeLoadBalancer fetches content from a load balancer
+	OriginTypeLoadBalancer OriginType = 3
+	// OriginTypeEdgeScript fetches content from the edge script in EdgeScriptId
+	OriginTypeEdgeScript OriginType = 4
+	// OriginTypeMagicContainers fetches content from a Magic Containers application
+	OriginTypeMagicContainers OriginType = 5
+	// OriginTypePushZone serves content pushed to the zone
+	OriginTypePushZone OriginType = 6
+)
+
+// LogFormat represents the format of the logs stored for a Pull Zone
+type LogFormat int
+
+const (
+	// LogFormatPlain writes logs as plain text lines
+	LogFormatPlain LogFormat = 0
+	// LogFormatJSON writes logs as JSON objects
+	LogFormatJSON LogFormat = 1
+)
+
+// LogForwardingFormat represents the format of forwarded log lines
+type LogForwardingFormat int
+
+const (
+	// LogForwardingFormatPlain forwards logs as plain text lines
+	LogForwardingFormatPlain LogForwardingFormat = 0
+	// LogForwardingFormatJSON forwards logs as JSON objects
+	LogForwardingFormatJSON LogForwardingFormat = 1
+)
+
+// LogForwardingProtocol represents the protocol used to forward logs
+type LogForwardingProtocol int
+
+const (
+	// LogForwardingProtocolUDP forwards logs over syslog UDP
+	LogForwardingProtocolUDP LogForwardingProtocol = 0
+	// LogForwardingProtocolTCP forwards logs over syslog TCP
+	LogForwardingProtocolTCP LogForwardingProtocol = 1
+	// LogForwardingProtocolTCPEncrypted forwards logs over syslog TCP with TLS
+	LogForwardingProtocolTCPEncrypted LogForwardingProtocol = 2
+	// LogForwardingProtocolDataDog forwards logs to DataDog
+	LogForwardingProtocolDataDog LogForwardingProtocol = 3
+)
+
 // PullZone represents a Pull Zone in the Bunny.net API
 type PullZone struct {
 	// Id is the unique identifier of the pull zone
@@ -84,8 +149,8 @@ type PullZone struct {
 	// OriginHostHeader determines the host header that will be sent to the origin
 	OriginHostHeader string `json:"OriginHostHeader"`
 
-	// Type is the type of pull zone (0 = Premium, 1 = Volume)
-	Type int `json:"Type"`
+	// Type is the type of pull zone
+	Type PullZoneType `json:"Type"`
 
 	// AccessControlOriginHeaderExtensions is the list of extensions that will return the CORS headers
 	AccessControlOriginHeaderExtensions []string `json:"AccessControlOriginHeaderExtensions"`
@@ -195,8 +260,8 @@ type PullZone struct {
 	// LogForwardingToken is the log forwarding token value
 	LogForwardingToken string `json:"LogForwardingToken"`
 
-	// LogForwardingProtocol is the protocol used for log forwarding (0 = UDP, 1 = TCP, 2 = TCPEncrypted, 3 = DataDog)
-	LogForwardingProtocol int `json:"LogForwardingProtocol"`
+	// LogForwardingProtocol is the protocol used for log forwarding
+	LogForwardingProtocol LogForwardingProtocol `json:"LogForwardingProtocol"`
 
 	// LoggingSaveToStorage determines if the permanent logging feature is enabled
 	LoggingSaveToStorage bool `json:"LoggingSaveToStorage"`
@@ -264,17 +329,17 @@ type PullZone struct {
 	// EnableQueryStringOrdering if set to true the query string ordering property is enabled
 	EnableQueryStringOrdering bool `json:"EnableQueryStringOrdering"`
 
-	// LogAnonymizationType sets the type of log anonymization (0 = OneDigit, 1 = Drop)
-	LogAnonymizationType int `json:"LogAnonymizationType"`
+	// LogAnonymizationType sets the type of log anonymization
+	LogAnonymizationType LogAnonymizationType `json:"LogAnonymizationType"`
 
-	// LogFormat sets the log format (0 = Plain, 1 = JSON)
-	LogFormat int `json:"LogFormat"`
+	// LogFormat sets the log format
+	LogFormat LogFormat `json:"LogFormat"`
 
-	// LogForwardingFormat sets the log forwarding format (0 = Plain, 1 = JSON)
-	LogForwardingFormat int `json:"LogForwardingFormat"`
+	// LogForwardingFormat sets the log forwarding format
+	LogForwardingFormat LogForwardingFormat `json:"LogForwardingFormat"`
 
-	// OriginType sets the origin type (0 = OriginUrl, 1 = DnsAccelerate, etc)
-	OriginType int `json:"OriginType"`
+	// OriginType sets the origin type
+	OriginType OriginType `json:"OriginType"`
 
 	// EnableRequestCoalescing determines if request coalescing is currently enabled
 	EnableRequestCoalescing bool `json:"EnableRequestCoalescing"`
@@ -322,7 +387,7 @@ type EdgeRule struct {
 	Guid string `json:"Guid"`
 
 	// ActionType is the type of action that the edge rule performs
-	ActionType int `json:"ActionType"`
+	ActionType EdgeRuleActionType `json:"ActionType"`
 
 	// ActionParameter1 is the action parameter 1
 	ActionParameter1 string `json:"ActionParameter1"`
@@ -333,6 +398,9 @@ type EdgeRule struct {
 	// Triggers is the list of triggers for this edge rule
 	Triggers []EdgeRuleTrigger `json:"Triggers"`
 
+	// TriggerMatchingType defines how the triggers of the edge rule are combined
+	TriggerMatchingType TriggerMatchingType `json:"TriggerMatchingType"`
+
 	// Description is the description of the edge rule
 	Description string `json:"Description"`
 
@@ -343,19 +411,19 @@ type EdgeRule struct {
 // EdgeRuleTrigger represents a trigger for an edge rule
 type EdgeRuleTrigger struct {
 	// Type is the type of trigger
-	Type int `json:"Type"`
+	Type EdgeRuleTriggerType `json:"Type"`
 
 	// PatternMatches is the list of pattern matches that will trigger the edge rule
 	PatternMatches []string `json:"PatternMatches"`
 
 	// PatternMatchingType defines how patterns should be matched
-	PatternMatchingType int `json:"PatternMatchingType"`
+	PatternMatchingType PatternMatchingType `json:"PatternMatchingType"`
 
 	// Parameter1 is the trigger parameter 1
 	Parameter1 string `json:"Parameter1"`
 
 	// TriggerMatchingType defines how triggers should be matched
-	TriggerMatchingType int `json:"TriggerMatchingType"`
+	TriggerMatchingType TriggerMatchingType `json:"TriggerMatchingType"`
 }
 
 // Add PullZone request parameters
@@ -366,8 +434,8 @@ type AddPullZoneOptions struct {
 	// OriginUrl is the origin URL of the Pull Zone
 	OriginUrl string `json:"OriginUrl"`
 
-	// Type is the type of pull zone (0 = Premium, 1 = Volume)
-	Type int `json:"Type,omitempty"`
+	// Type is the type of pull zone
+	Type PullZoneType `json:"Type,omitempty"`
 
 	// Additional configuration parameters can be added here
 	// The following are just some examples
@@ -382,6 +450,266 @@ type AddPullZoneOptions struct {
 	// Other options - can be expanded as needed
 }
 
+// UpdatePullZoneOptions represents the options for a partial pull zone update.
+// Only fields that are set are sent to the API, so a field can be changed to its zero value
+// without overwriting unrelated settings. Use common.Ptr to set individual fields.
+type UpdatePullZoneOptions struct {
+	// OriginUrl is the origin URL of the pull zone where the files are fetched from
+	OriginUrl *string `json:"OriginUrl,omitempty"`
+
+	// Enabled determines if the Pull Zone is currently enabled, active and running
+	Enabled *bool `json:"Enabled,omitempty"`
+
+	// StorageZoneId is the ID of the storage zone that the pull zone is linked to
+	StorageZoneId *int64 `json:"StorageZoneId,omitempty"`
+
+	// EdgeScriptId is the ID of the edge script that the pull zone is linked to
+	EdgeScriptId *int64 `json:"EdgeScriptId,omitempty"`
+
+	// AllowedReferrers is the list of referrer hostnames that are allowed to access the pull zone
+	AllowedReferrers *[]string `json:"AllowedReferrers,omitempty"`
+
+	// BlockedReferrers is the list of referrer hostnames that are blocked from accessing the pull zone
+	BlockedReferrers *[]string `json:"BlockedReferrers,omitempty"`
+
+	// BlockedIps is the list of IPs that are blocked from accessing the pull zone
+	BlockedIps *[]string `json:"BlockedIps,omitempty"`
+
+	// EnableGeoZoneUS determines if the delivery from the North American region is enabled for this pull zone
+	EnableGeoZoneUS *bool `json:"EnableGeoZoneUS,omitempty"`
+
+	// EnableGeoZoneEU determines if the delivery from the European region is enabled for this pull zone
+	EnableGeoZoneEU *bool `json:"EnableGeoZoneEU,omitempty"`
+
+	// EnableGeoZoneASIA determines if the delivery from the Asian / Oceanian region is enabled for this pull zone
+	EnableGeoZoneASIA *bool `json:"EnableGeoZoneASIA,omitempty"`
+
+	// EnableGeoZoneSA determines if the delivery from the South American region is enabled for this pull zone
+	EnableGeoZoneSA *bool `json:"EnableGeoZoneSA,omitempty"`
+
+	// EnableGeoZoneAF determines if the delivery from the Africa region is enabled for this pull zone
+	EnableGeoZoneAF *bool `json:"EnableGeoZoneAF,omitempty"`
+
+	// ZoneSecurityEnabled is true if the URL secure token authentication security is enabled
+	ZoneSecurityEnabled *bool `json:"ZoneSecurityEnabled,omitempty"`
+
+	// ZoneSecurityIncludeHashRemoteIP is true if the zone security hash should include the remote IP
+	ZoneSecurityIncludeHashRemoteIP *bool `json:"ZoneSecurityIncludeHashRemoteIP,omitempty"`
+
+	// IgnoreQueryStrings is true if the Pull Zone is ignoring query strings when serving cached objects
+	IgnoreQueryStrings *bool `json:"IgnoreQueryStrings,omitempty"`
+
+	// MonthlyBandwidthLimit is the monthly limit of bandwidth in bytes that the pullzone is allowed to use
+	MonthlyBandwidthLimit *int64 `json:"MonthlyBandwidthLimit,omitempty"`
+
+	// AddHostHeader determines if the Pull Zone should forward the current hostname to the origin
+	AddHostHeader *bool `json:"AddHostHeader,omitempty"`
+
+	// OriginHostHeader determines the host header that will be sent to the origin
+	OriginHostHeader *string `json:"OriginHostHeader,omitempty"`
+
+	// Type is the type of pull zone
+	Type *PullZoneType `json:"Type,omitempty"`
+
+	// AccessControlOriginHeaderExtensions is the list of extensions that will return the CORS headers
+	AccessControlOriginHeaderExtensions *[]string `json:"AccessControlOriginHeaderExtensions,omitempty"`
+
+	// EnableAccessControlOriginHeader determines if the CORS headers should be enabled
+	EnableAccessControlOriginHeader *bool `json:"EnableAccessControlOriginHeader,omitempty"`
+
+	// DisableCookies determines if the cookies are disabled for the pull zone
+	DisableCookies *bool `json:"DisableCookies,omitempty"`
+
+	// BudgetRedirectedCountries is the list of budget redirected countries with the two-letter Alpha2 ISO codes
+	BudgetRedirectedCountries *[]string `json:"BudgetRedirectedCountries,omitempty"`
+
+	// BlockedCountries is the list of blocked countries with the two-letter Alpha2 ISO codes
+	BlockedCountries *[]string `json:"BlockedCountries,omitempty"`
+
+	// EnableOriginShield if true the server will use the origin shield feature
+	EnableOriginShield *bool `json:"EnableOriginShield,omitempty"`
+
+	// CacheControlMaxAgeOverride is the override cache time for the pull zone
+	CacheControlMaxAgeOverride *int64 `json:"CacheControlMaxAgeOverride,omitempty"`
+
+	// CacheControlPublicMaxAgeOverride is the override cache time for the pull zone for the end client
+	CacheControlPublicMaxAgeOverride *int64 `json:"CacheControlPublicMaxAgeOverride,omitempty"`
+
+	// BurstSize - excessive requests are delayed until their number exceeds the maximum burst size
+	BurstSize *int32 `json:"BurstSize,omitempty"`
+
+	// RequestLimit - max number of requests per IP per second
+	RequestLimit *int32 `json:"RequestLimit,omitempty"`
+
+	// BlockRootPathAccess if true, access to root path will return a 403 error
+	BlockRootPathAccess *bool `json:"BlockRootPathAccess,omitempty"`
+
+	// BlockPostRequests if true, POST requests to the zone will be blocked
+	BlockPostRequests *bool `json:"BlockPostRequests,omitempty"`
+
+	// LimitRatePerSecond is the maximum rate at which the zone will transfer data in kb/s. 0 for unlimited
+	LimitRatePerSecond *float64 `json:"LimitRatePerSecond,omitempty"`
+
+	// LimitRateAfter is the amount of data after the rate limit will be activated
+	LimitRateAfter *float64 `json:"LimitRateAfter,omitempty"`
+
+	// ConnectionLimitPerIPCount is the number of connections limited per IP for this zone
+	ConnectionLimitPerIPCount *int32 `json:"ConnectionLimitPerIPCount,omitempty"`
+
+	// AddCanonicalHeader determines if the Add Canonical Header is enabled for this Pull Zone
+	AddCanonicalHeader *bool `json:"AddCanonicalHeader,omitempty"`
+
+	// EnableLogging determines if the logging is enabled for this Pull Zone
+	EnableLogging *bool `json:"EnableLogging,omitempty"`
+
+	// EnableCacheSlice determines if the cache slice (Optimize for video) feature is enabled for the Pull Zone
+	EnableCacheSlice *bool `json:"EnableCacheSlice,omitempty"`
+
+	// EnableSmartCache determines if smart caching is enabled for this zone
+	EnableSmartCache *bool `json:"EnableSmartCache,omitempty"`
+
+	// EnableWebPVary determines if the WebP Vary feature is enabled
+	EnableWebPVary *bool `json:"EnableWebPVary,omitempty"`
+
+	// EnableAvifVary determines if the AVIF Vary feature is enabled
+	EnableAvifVary *bool `json:"EnableAvifVary,omitempty"`
+
+	// EnableCountryCodeVary determines if the Country Code Vary feature is enabled
+	EnableCountryCodeVary *bool `json:"EnableCountryCodeVary,omitempty"`
+
+	// EnableMobileVary determines if the Mobile Vary feature is enabled
+	EnableMobileVary *bool `json:"EnableMobileVary,omitempty"`
+
+	// EnableCookieVary determines if the Cookie Vary feature is enabled
+	EnableCookieVary *bool `json:"EnableCookieVary,omitempty"`
+
+	// CookieVaryParameters contains the list of vary parameters that will be used for vary cache by cookie string
+	CookieVaryParameters *[]string `json:"CookieVaryParameters,omitempty"`
+
+	// EnableHostnameVary determines if the Hostname Vary feature is enabled
+	EnableHostnameVary *bool `json:"EnableHostnameVary,omitempty"`
+
+	// LoggingIPAnonymizationEnabled determines if IP anonymization is enabled for logs
+	LoggingIPAnonymizationEnabled *bool `json:"LoggingIPAnonymizationEnabled,omitempty"`
+
+	// EnableTLS1 determines if the TLS 1 is enabled on the Pull Zone
+	EnableTLS1 *bool `json:"EnableTLS1,omitempty"`
+
+	// EnableTLS1_1 determines if the TLS 1.1 is enabled on the Pull Zone
+	EnableTLS1_1 *bool `json:"EnableTLS1_1,omitempty"`
+
+	// VerifyOriginSSL determines if the Pull Zone should verify the origin SSL certificate
+	VerifyOriginSSL *bool `json:"VerifyOriginSSL,omitempty"`
+
+	// LogForwardingEnabled determines if the log forwarding is enabled
+	LogForwardingEnabled *bool `json:"LogForwardingEnabled,omitempty"`
+
+	// LogForwardingHostname is the log forwarding hostname
+	LogForwardingHostname *string `json:"LogForwardingHostname,omitempty"`
+
+	// LogForwardingPort is the log forwarding port
+	LogForwardingPort *int32 `json:"LogForwardingPort,omitempty"`
+
+	// LogForwardingToken is the log forwarding token value
+	LogForwardingToken *string `json:"LogForwardingToken,omitempty"`
+
+	// LogForwardingProtocol is the protocol used for log forwarding
+	LogForwardingProtocol *LogForwardingProtocol `json:"LogForwardingProtocol,omitempty"`
+
+	// LoggingSaveToStorage determines if the permanent logging feature is enabled
+	LoggingSaveToStorage *bool `json:"LoggingSaveToStorage,omitempty"`
+
+	// LoggingStorageZoneId is the ID of the logging storage zone that is configured for this Pull Zone
+	LoggingStorageZoneId *int64 `json:"LoggingStorageZoneId,omitempty"`
+
+	// FollowRedirects determines if the zone will follow origin redirects
+	FollowRedirects *bool `json:"FollowRedirects,omitempty"`
+
+	// OriginRetries is the number of retries to the origin server
+	OriginRetries *int32 `json:"OriginRetries,omitempty"`
+
+	// OriginConnectTimeout is the amount of seconds to wait when connecting to the origin
+	OriginConnectTimeout *int32 `json:"OriginConnectTimeout,omitempty"`
+
+	// OriginResponseTimeout is the amount of seconds to wait when waiting for the origin reply
+	OriginResponseTimeout *int32 `json:"OriginResponseTimeout,omitempty"`
+
+	// UseStaleWhileUpdating determines if we should use stale cache while cache is updating
+	UseStaleWhileUpdating *bool `json:"UseStaleWhileUpdating,omitempty"`
+
+	// UseStaleWhileOffline determines if we should use stale cache while the origin is offline
+	UseStaleWhileOffline *bool `json:"UseStaleWhileOffline,omitempty"`
+
+	// OriginRetry5XXResponses determines if we should retry the request in case of a 5XX response
+	OriginRetry5XXResponses *bool `json:"OriginRetry5XXResponses,omitempty"`
+
+	// OriginRetryConnectionTimeout determines if we should retry the request in case of a connection timeout
+	OriginRetryConnectionTimeout *bool `json:"OriginRetryConnectionTimeout,omitempty"`
+
+	// OriginRetryResponseTimeout determines if we should retry the request in case of a response timeout
+	OriginRetryResponseTimeout *bool `json:"OriginRetryResponseTimeout,omitempty"`
+
+	// OriginRetryDelay determines the amount of time that the CDN should wait before retrying an origin request
+	OriginRetryDelay *int32 `json:"OriginRetryDelay,omitempty"`
+
+	// QueryStringVaryParameters contains the list of vary parameters for vary cache by query string
+	QueryStringVaryParameters *[]string `json:"QueryStringVaryParameters,omitempty"`
+
+	// OriginShieldEnableConcurrencyLimit determines if the origin shield concurrency limit is enabled
+	OriginShieldEnableConcurrencyLimit *bool `json:"OriginShieldEnableConcurrencyLimit,omitempty"`
+
+	// OriginShieldMaxConcurrentRequests determines the number of maximum concurrent requests allowed to the origin
+	OriginShieldMaxConcurrentRequests *int32 `json:"OriginShieldMaxConcurrentRequests,omitempty"`
+
+	// EnableSafeHop enables the SafeHop feature
+	EnableSafeHop *bool `json:"EnableSafeHop,omitempty"`
+
+	// CacheErrorResponses determines if bunny.net should be caching error responses
+	CacheErrorResponses *bool `json:"CacheErrorResponses,omitempty"`
+
+	// OriginShieldQueueMaxWaitTime determines the max queue wait time
+	OriginShieldQueueMaxWaitTime *int32 `json:"OriginShieldQueueMaxWaitTime,omitempty"`
+
+	// OriginShieldMaxQueuedRequests determines the max number of origin requests that will remain in the queue
+	OriginShieldMaxQueuedRequests *int32 `json:"OriginShieldMaxQueuedRequests,omitempty"`
+
+	// UseBackgroundUpdate determines if cache update is performed in the background
+	UseBackgroundUpdate *bool `json:"UseBackgroundUpdate,omitempty"`
+
+	// EnableAutoSSL if set to true, any hostnames added to this Pull Zone will automatically enable SSL
+	EnableAutoSSL *bool `json:"EnableAutoSSL,omitempty"`
+
+	// EnableQueryStringOrdering if set to true the query string ordering property is enabled
+	EnableQueryStringOrdering *bool `json:"EnableQueryStringOrdering,omitempty"`
+
+	// LogAnonymizationType sets the type of log anonymization
+	LogAnonymizationType *LogAnonymizationType `json:"LogAnonymizationType,omitempty"`
+
+	// LogFormat sets the log format
+	LogFormat *LogFormat `json:"LogFormat,omitempty"`
+
+	// LogForwardingFormat sets the log forwarding format
+	LogForwardingFormat *LogForwardingFormat `json:"LogForwardingFormat,omitempty"`
+
+	// OriginType sets the origin type
+	OriginType *OriginType `json:"OriginType,omitempty"`
+
+	// EnableRequestCoalescing determines if request coalescing is currently enabled
+	EnableRequestCoalescing *bool `json:"EnableRequestCoalescing,omitempty"`
+
+	// RequestCoalescingTimeout determines the lock time for coalesced requests
+	RequestCoalescingTimeout *int32 `json:"RequestCoalescingTimeout,omitempty"`
+
+	// DisableLetsEncrypt if true, the built-in let's encrypt is disabled and requests are passed to the origin
+	DisableLetsEncrypt *bool `json:"DisableLetsEncrypt,omitempty"`
+
+	// PreloadingScreenEnabled determines if the preloading screen is currently enabled
+	PreloadingScreenEnabled *bool `json:"PreloadingScreenEnabled,omitempty"`
+
+	// PreloadingScreenLogoUrl is the preloading screen logo URL
+	PreloadingScreenLogoUrl *string `json:"PreloadingScreenLogoUrl,omitempty"`
+}
+
 // AddHostnameOptions represents the options for adding a hostname to a pull zone
 type AddHostnameOptions struct {
 	// Hostname is the hostname that will be added
@@ -439,7 +767,7 @@ type AddOrUpdateEdgeRuleOptions struct {
 	Guid string `json:"Guid,omitempty"`
 
 	// ActionType is the type of action that the edge rule performs
-	ActionType int `json:"ActionType"`
+	ActionType EdgeRuleActionType `json:"ActionType"`
 
 	// ActionParameter1 is the action parameter 1
 	ActionParameter1 string `json:"ActionParameter1,omitempty"`
@@ -450,6 +778,9 @@ type AddOrUpdateEdgeRuleOptions struct {
 	// Triggers is the list of triggers for this edge rule
 	Triggers []EdgeRuleTrigger `json:"Triggers"`
 
+	// TriggerMatchingType defines how the triggers of the edge rule are combined
+	TriggerMatchingType TriggerMatchingType `json:"TriggerMatchingType"`
+
 	// Description is the description of the edge rule
 	Description string `json:"Description,omitempty"`
 
@@ -710,6 +1041,85 @@ func (s *PullZoneService) Update(ctx context.Context, id int64, options *PullZon
 	return &updatedPullZone, nil
 }
 
+// UpdatePartial updates only the fields of a pull zone that are set in the options
+func (s *PullZoneService) UpdatePartial(ctx context.Context, id int64, options UpdatePullZoneOptions) (*PullZone, error) {
+	path := fmt.Sprintf("/pullzone/%d", id)
+	req, err := internal.NewRequest(http.MethodPost, s.baseURL, path, options, s.apiKey, s.userAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	resp, err := internal.DoRequest(s.client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedPullZone PullZone
+	if err := internal.ParseResponse(resp, &updatedPullZone); err != nil {
+		return nil, err
+	}
+
+	return &updatedPullZone, nil
+}
+
+// Patch sends the minimal update that turns original into modified.
+// If the two pull zones have no updatable differences, modified is returned without calling the API.
+func (s *PullZoneService) Patch(ctx context.Context, id int64, original, modified *PullZone) (*PullZone, error) {
+	options := DiffPullZones(original, modified)
+	if options.IsEmpty() {
+		return modified, nil
+	}
+
+	return s.UpdatePartial(ctx, id, options)
+}
+
+// DiffPullZones returns update options containing only the updatable fields that differ between original and modified.
+// Read-only fields and fields managed by dedicated endpoints, such as Hostnames and EdgeRules, are ignored.
+func DiffPullZones(original, modified *PullZone) UpdatePullZoneOptions {
+	var options UpdatePullZoneOptions
+
+	ov := reflect.ValueOf(original).Elem()
+	mv := reflect.ValueOf(modified).Elem()
+	uv := reflect.ValueOf(&options).Elem()
+
+	for i := 0; i < uv.NumField(); i++ {
+		name := uv.Type().Field(i).Name
+		before := ov.FieldByName(name)
+		after := mv.FieldByName(name)
+
+		if before.Kind() == reflect.Slice && before.Len() == 0 && after.Len() == 0 {
+			continue
+		}
+		if reflect.DeepEqual(before.Interface(), after.Interface()) {
+			continue
+		}
+
+		value := reflect.New(after.Type())
+		if after.Kind() == reflect.Slice && after.IsNil() {
+			// A nil slice would be sent as null, an empty slice clears the list
+			value.Elem().Set(reflect.MakeSlice(after.Type(), 0, 0))
+		} else {
+			value.Elem().Set(after)
+		}
+		uv.Field(i).Set(value)
+	}
+
+	return options
+}
+
+// IsEmpty returns true if no field is set in the options
+func (o UpdatePullZoneOptions) IsEmpty() bool {
+	v := reflect.ValueOf(o)
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).IsNil() {
+			return false
+		}
+	}
+	return true
+}
+
 // Delete deletes a pull zone
 func (s *PullZoneService) Delete(ctx context.Context, id int64) error {
 	path := fmt.Sprintf("/pullzone/%d", id)
@@ -978,6 +1388,10 @@ func (s *PullZoneService) RemoveBlockedIP(ctx context.Context, id int64, options
 
 // AddOrUpdateEdgeRule adds or updates an edge rule on a pull zone
 func (s *PullZoneService) AddOrUpdateEdgeRule(ctx context.Context, pullZoneId int64, options AddOrUpdateEdgeRuleOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+
 	path := fmt.Sprintf("/pullzone/%d/edgerules/addOrUpdate", pullZoneId)
 	req, err := internal.NewRequest(http.MethodPost, s.baseURL, path, options, s.apiKey, s.userAgent)
 	if err != nil {
@@ -0,0 +1,235 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/common"
+)
+
+// templateManagedFields lists the PullZone fields that CreateFromTemplate copies through dedicated
+// endpoints or that belong to the new zone, so they are never reported as skipped
+var templateManagedFields = map[string]bool{
+	"Id":                   true,
+	"Name":                 true,
+	"Hostnames":            true,
+	"EdgeRules":            true,
+	"CnameDomain":          true,
+	"MonthlyBandwidthUsed": true,
+	"MonthlyCharges":       true,
+}
+
+// CloneOptions represents the options for creating a Pull Zone from another zone or a template
+type CloneOptions struct {
+	// OriginUrl replaces the origin URL of the source, such as for a new site with its own origin
+	OriginUrl string
+
+	// Hostnames adds the custom hostnames of the source to the new zone. A hostname can only belong to
+	// one Pull Zone, so this is mostly useful for templates and for recreating a deleted zone.
+	Hostnames bool
+
+	// NoEdgeRules skips copying the edge rules of the source
+	NoEdgeRules bool
+}
+
+// SkippedSetting represents a setting that could not be copied to the new Pull Zone
+type SkippedSetting struct {
+	// Setting is the name of the field, or the edge rule or hostname that was not copied
+	Setting string
+
+	// Reason explains why the setting was not copied
+	Reason string
+
+	// Err is the error returned by the API, nil for settings the API does not allow copying
+	Err error
+}
+
+// CloneReport represents the result of creating a Pull Zone from another zone or a template
+type CloneReport struct {
+	// SourceId is the ID of the cloned Pull Zone, 0 for templates
+	SourceId int64
+
+	// PullZone is the new Pull Zone, set as soon as it has been created
+	PullZone *PullZone
+
+	// EdgeRules is the number of edge rules copied
+	EdgeRules int
+
+	// Hostnames is the list of custom hostnames added
+	Hostnames []string
+
+	// Skipped is the list of settings that could not be copied
+	Skipped []SkippedSetting
+}
+
+// Clone creates a Pull Zone named newName with the settings of an existing zone: the cache, security,
+// vary, origin and logging settings, the referrer and IP lists and the edge rules, and with
+// CloneOptions.Hostnames the custom hostnames. See CreateFromTemplate for what cannot be copied.
+func (s *PullZoneService) Clone(ctx context.Context, sourceId int64, newName string, opts *CloneOptions) (*CloneReport, error) {
+	source, err := s.Get(ctx, sourceId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.CreateFromTemplate(ctx, newName, source, opts)
+	if report != nil {
+		report.SourceId = sourceId
+	}
+	return report, err
+}
+
+// CreateFromTemplate creates a Pull Zone named name with the settings of template, which can be a
+// zone returned by Get or one loaded from a file. The zone is created with the origin and type of
+// the template, updated with every other setting in one request, then the edge rules and optionally
+// the hostnames are added one by one.
+//
+// The zone security key and hostname certificates cannot be copied and are reported as skipped, as are
+// edge rules and hostnames the API rejects. A rejected settings update is reported as the skipped
+// setting "Settings" and the edge rules and hostnames are still copied. When the zone cannot be
+// created no report is returned; when a later step fails the report holds the new zone so that it
+// can be deleted or fixed, and the error joins the errors of all failed steps.
+func (s *PullZoneService) CreateFromTemplate(ctx context.Context, name string, template *PullZone, opts *CloneOptions) (*CloneReport, error) {
+	if opts == nil {
+		opts = &CloneOptions{}
+	}
+
+	originUrl := template.OriginUrl
+	if opts.OriginUrl != "" {
+		originUrl = opts.OriginUrl
+	}
+
+	zone, err := s.Add(ctx, AddPullZoneOptions{
+		Name:      name,
+		OriginUrl: originUrl,
+		Type:      template.Type,
+	})
+	if err != nil {
+		return nil, err
+	}
+	report := &CloneReport{PullZone: zone}
+
+	modified := *template
+	modified.OriginUrl = originUrl
+	if _, err := s.Patch(ctx, zone.Id, zone, &modified); err != nil {
+		report.Skipped = append(report.Skipped, SkippedSetting{
+			Setting: "Settings",
+			Reason:  "the API rejected the settings update, the zone keeps its default settings",
+			Err:     err,
+		})
+	}
+
+	if template.ZoneSecurityKey != "" {
+		report.Skipped = append(report.Skipped, SkippedSetting{
+			Setting: "ZoneSecurityKey",
+			Reason:  "every Pull Zone has its own security key, signed URLs must use the key of the new zone",
+		})
+	}
+	report.Skipped = append(report.Skipped, uncopiedSettings(template)...)
+
+	if !opts.NoEdgeRules {
+		for _, rule := range template.EdgeRules {
+			options := rule.Options()
+			options.Guid = ""
+			if err := s.AddOrUpdateEdgeRule(ctx, zone.Id, options); err != nil {
+				reason := "the API rejected the edge rule"
+				var validationErr *common.ValidationError
+				if errors.As(err, &validationErr) {
+					reason = "the edge rule failed client-side validation and was not sent"
+				}
+				report.Skipped = append(report.Skipped, SkippedSetting{
+					Setting: "EdgeRule " + edgeRuleName(rule),
+					Reason:  reason,
+					Err:     err,
+				})
+				continue
+			}
+			report.EdgeRules++
+		}
+	}
+
+	if opts.Hostnames {
+		for _, hostname := range template.Hostnames {
+			if !hostname.IsSystemHostname {
+				s.copyHostname(ctx, zone.Id, hostname, report)
+			}
+		}
+	}
+
+	if updated, err := s.Get(ctx, zone.Id, false); err == nil {
+		report.PullZone = updated
+	}
+
+	var errs []error
+	for _, skipped := range report.Skipped {
+		if skipped.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", skipped.Setting, skipped.Err))
+		}
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// copyHostname adds a custom hostname of the template to the new zone with its Force SSL setting
+func (s *PullZoneService) copyHostname(ctx context.Context, id int64, hostname Hostname, report *CloneReport) {
+	setting := "Hostname " + hostname.Value
+
+	if err := s.AddHostname(ctx, id, AddHostnameOptions{Hostname: hostname.Value}); err != nil {
+		report.Skipped = append(report.Skipped, SkippedSetting{
+			Setting: setting,
+			Reason:  "the hostname could not be added, it may still belong to another Pull Zone",
+			Err:     err,
+		})
+		return
+	}
+	report.Hostnames = append(report.Hostnames, hostname.Value)
+
+	if hostname.HasCertificate {
+		report.Skipped = append(report.Skipped, SkippedSetting{
+			Setting: setting + " certificate",
+			Reason:  "certificates are not copied, load a free certificate or upload the certificate again",
+		})
+	}
+
+	if hostname.ForceSSL {
+		err := s.SetForceSSL(ctx, id, SetForceSSLOptions{Hostname: hostname.Value, ForceSSL: true})
+		if err != nil {
+			report.Skipped = append(report.Skipped, SkippedSetting{
+				Setting: setting + " ForceSSL",
+				Reason:  "Force SSL could not be enabled",
+				Err:     err,
+			})
+		}
+	}
+}
+
+// uncopiedSettings reports the set fields of the template that cannot be sent in a partial update,
+// so that fields added to PullZone without update support are not dropped silently
+func uncopiedSettings(template *PullZone) []SkippedSetting {
+	var skipped []SkippedSetting
+
+	tv := reflect.ValueOf(template).Elem()
+	updatable := reflect.TypeOf(UpdatePullZoneOptions{})
+	for i := 0; i < tv.NumField(); i++ {
+		name := tv.Type().Field(i).Name
+		if templateManagedFields[name] || name == "ZoneSecurityKey" {
+			continue
+		}
+		if _, ok := updatable.FieldByName(name); ok || tv.Field(i).IsZero() {
+			continue
+		}
+		skipped = append(skipped, SkippedSetting{Setting: name, Reason: "the API does not allow updating this setting"})
+	}
+
+	return skipped
+}
+
+// edgeRuleName names an edge rule in a report by its description, or its action when it has none
+func edgeRuleName(rule EdgeRule) string {
+	if description := strings.TrimSpace(rule.Description); description != "" {
+		return fmt.Sprintf("%q", description)
+	}
+	return fmt.Sprintf("%s (action %d)", rule.Guid, rule.ActionType)
+}
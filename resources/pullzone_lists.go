@@ -0,0 +1,354 @@
+package resources
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/venom90/bunnynet-go/common"
+)
+
+// ListChange represents an entry added to or removed from a Pull Zone list
+type ListChange struct {
+	// Action is SyncActionAdd or SyncActionDelete
+	Action SyncAction
+
+	// Value is the blocked IP or referrer hostname
+	Value string
+
+	// Err is the error returned when applying the change, nil on success or in a dry run
+	Err error
+}
+
+// ListSyncReport represents the result of synchronizing a Pull Zone list
+type ListSyncReport struct {
+	// PullZoneId is the ID of the Pull Zone
+	PullZoneId int64
+
+	// List is the name of the synchronized list, such as "BlockedIps"
+	List string
+
+	// DryRun indicates that the changes were computed but not applied
+	DryRun bool
+
+	// Changes is the list of changes, deletions first
+	Changes []ListChange
+
+	// Unchanged is the number of entries that were already in the list
+	Unchanged int
+
+	// Kept is the list of entries that are not desired but were kept because of NoDelete
+	Kept []string
+}
+
+// Failed returns the changes that could not be applied
+func (r *ListSyncReport) Failed() []ListChange {
+	var failed []ListChange
+	for _, change := range r.Changes {
+		if change.Err != nil {
+			failed = append(failed, change)
+		}
+	}
+	return failed
+}
+
+// listSync describes a Pull Zone list for syncList
+type listSync struct {
+	// name is the PullZone field of the list
+	name string
+
+	// current returns the live entries of the list
+	current func(zone *PullZone) []string
+
+	// normalize returns the comparable form of an entry
+	normalize func(value string) string
+
+	// add and remove change a single entry
+	add, remove func(ctx context.Context, id int64, value string) error
+}
+
+// SetBlockedIPs makes the blocked IPs of a Pull Zone match the desired addresses and CIDR ranges,
+// adding and removing only the entries that differ. Single addresses are sent without a prefix length
+// and IPv4-mapped IPv6 addresses as IPv4. All prefixes are validated before any change is made.
+// SyncOptions.NoDelete keeps entries that are not desired, which suits merging a threat feed into a
+// manually maintained list.
+//
+// The report is returned even when some changes fail; the error then joins the errors of all failed changes.
+func (s *PullZoneService) SetBlockedIPs(ctx context.Context, id int64, desired []netip.Prefix, opts *SyncOptions) (*ListSyncReport, error) {
+	errs := &common.ValidationError{}
+	values := make([]string, 0, len(desired))
+	for i, prefix := range desired {
+		if !prefix.IsValid() {
+			errs.Add(fmt.Sprintf("BlockedIps[%d]", i), "invalid prefix")
+			continue
+		}
+		if prefix != prefix.Masked() {
+			errs.Add(fmt.Sprintf("BlockedIps[%d]", i), "%s has host bits set, use %s", prefix, prefix.Masked())
+			continue
+		}
+		values = append(values, blockedIPValue(prefix))
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return s.syncList(ctx, id, values, opts, listSync{
+		name:      "BlockedIps",
+		current:   func(zone *PullZone) []string { return zone.BlockedIps },
+		normalize: normalizeBlockedIP,
+		add: func(ctx context.Context, id int64, value string) error {
+			return s.AddBlockedIP(ctx, id, BlockedIPOptions{BlockedIp: value})
+		},
+		remove: func(ctx context.Context, id int64, value string) error {
+			return s.RemoveBlockedIP(ctx, id, BlockedIPOptions{BlockedIp: value})
+		},
+	})
+}
+
+// SetAllowedReferrers makes the allowed referrers of a Pull Zone match the desired hostnames,
+// which may start with a "*." wildcard. Hostnames are compared case-insensitively.
+func (s *PullZoneService) SetAllowedReferrers(ctx context.Context, id int64, desired []string, opts *SyncOptions) (*ListSyncReport, error) {
+	values, err := validateReferrers("AllowedReferrers", desired)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.syncList(ctx, id, values, opts, listSync{
+		name:      "AllowedReferrers",
+		current:   func(zone *PullZone) []string { return zone.AllowedReferrers },
+		normalize: normalizeReferrer,
+		add: func(ctx context.Context, id int64, value string) error {
+			return s.AddAllowedReferrer(ctx, id, HostnameOptions{Hostname: value})
+		},
+		remove: func(ctx context.Context, id int64, value string) error {
+			return s.RemoveAllowedReferrer(ctx, id, HostnameOptions{Hostname: value})
+		},
+	})
+}
+
+// SetBlockedReferrers makes the blocked referrers of a Pull Zone match the desired hostnames,
+// which may start with a "*." wildcard. Hostnames are compared case-insensitively.
+func (s *PullZoneService) SetBlockedReferrers(ctx context.Context, id int64, desired []string, opts *SyncOptions) (*ListSyncReport, error) {
+	values, err := validateReferrers("BlockedReferrers", desired)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.syncList(ctx, id, values, opts, listSync{
+		name:      "BlockedReferrers",
+		current:   func(zone *PullZone) []string { return zone.BlockedReferrers },
+		normalize: normalizeReferrer,
+		add: func(ctx context.Context, id int64, value string) error {
+			return s.AddBlockedReferrer(ctx, id, HostnameOptions{Hostname: value})
+		},
+		remove: func(ctx context.Context, id int64, value string) error {
+			return s.RemoveBlockedReferrer(ctx, id, HostnameOptions{Hostname: value})
+		},
+	})
+}
+
+// ParseThreatFeed reads IP addresses and CIDR ranges from a threat feed, one per line, such as the
+// Spamhaus DROP list or a plain blocklist. Blank lines and comments starting with "#" or ";" are
+// skipped, as is anything after the first address on a line. Duplicates are removed and host bits
+// are cleared.
+func ParseThreatFeed(r io.Reader) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	seen := make(map[netip.Prefix]bool)
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		text := scanner.Text()
+		if i := strings.IndexAny(text, "#;"); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.FieldsFunc(text, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ','
+		})
+		if len(fields) == 0 {
+			continue
+		}
+
+		prefix, err := parseBlockedIP(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("threat feed line %d: %w", line, err)
+		}
+		if !seen[prefix] {
+			seen[prefix] = true
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return prefixes, nil
+}
+
+// syncList computes and applies the minimal changes that turn the live list into the desired one
+func (s *PullZoneService) syncList(ctx context.Context, id int64, desired []string, opts *SyncOptions, list listSync) (*ListSyncReport, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+
+	zone, err := s.Get(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ListSyncReport{PullZoneId: id, List: list.name, DryRun: opts.DryRun}
+
+	wanted := make(map[string]string, len(desired))
+	for _, value := range desired {
+		wanted[list.normalize(value)] = value
+	}
+
+	var deletes, adds []ListChange
+	live := make(map[string]bool)
+	for _, value := range list.current(zone) {
+		key := list.normalize(value)
+		if _, ok := wanted[key]; ok {
+			// Live duplicates are left alone, removing one could remove the desired entry as well
+			if !live[key] {
+				live[key] = true
+				report.Unchanged++
+			}
+			continue
+		}
+		if opts.NoDelete {
+			report.Kept = append(report.Kept, value)
+			continue
+		}
+		// The live value is removed as is, so that entries in another format still match on the API side
+		deletes = append(deletes, ListChange{Action: SyncActionDelete, Value: value})
+	}
+	for key, value := range wanted {
+		if !live[key] {
+			adds = append(adds, ListChange{Action: SyncActionAdd, Value: value})
+		}
+	}
+
+	for _, changes := range [][]ListChange{deletes, adds} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Value < changes[j].Value })
+	}
+	report.Changes = append(deletes, adds...)
+
+	if !opts.DryRun {
+		concurrency := opts.Concurrency
+		if concurrency <= 0 {
+			concurrency = defaultSyncConcurrency
+		}
+		applyListChanges(ctx, id, report.Changes, concurrency, list)
+	}
+
+	var errs []error
+	for _, change := range report.Failed() {
+		errs = append(errs, fmt.Errorf("%s %s %s: %w", change.Action, list.name, change.Value, change.Err))
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// applyListChanges applies list changes with bounded concurrency, recording the result on each change
+func applyListChanges(ctx context.Context, id int64, changes []ListChange, concurrency int, list listSync) {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := range changes {
+		change := &changes[i]
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			change.Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if change.Action == SyncActionDelete {
+				change.Err = list.remove(ctx, id, change.Value)
+			} else {
+				change.Err = list.add(ctx, id, change.Value)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// validateReferrers checks and normalizes referrer hostnames, returning all invalid entries together
+func validateReferrers(field string, desired []string) ([]string, error) {
+	errs := &common.ValidationError{}
+	values := make([]string, 0, len(desired))
+	for i, value := range desired {
+		normalized := normalizeReferrer(value)
+		if !validReferrer(normalized) {
+			errs.Add(fmt.Sprintf("%s[%d]", field, i), "%q is not a valid hostname", value)
+			continue
+		}
+		values = append(values, normalized)
+	}
+	return values, errs.ErrorOrNil()
+}
+
+// validReferrer reports whether a referrer is a hostname, optionally with a leading "*." wildcard
+func validReferrer(value string) bool {
+	return validDomainName(strings.TrimPrefix(value, "*."))
+}
+
+// normalizeReferrer lowercases a referrer hostname and removes surrounding space and the trailing dot
+func normalizeReferrer(value string) string {
+	return common.NormalizeDomain(value)
+}
+
+// parseBlockedIP parses an IP address or CIDR range, clearing host bits
+func parseBlockedIP(value string) (netip.Prefix, error) {
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("%q is not an IP address or CIDR range", value)
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%q is not an IP address or CIDR range", value)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// normalizeBlockedIP returns the comparable form of a live blocked IP, entries that are not addresses
+// or ranges, such as wildcards, are compared as they are
+func normalizeBlockedIP(value string) string {
+	prefix, err := parseBlockedIP(strings.TrimSpace(value))
+	if err != nil {
+		return strings.TrimSpace(value)
+	}
+	return blockedIPValue(prefix)
+}
+
+// blockedIPValue formats a prefix as the API expects, single addresses without a prefix length and
+// IPv4-mapped IPv6 addresses and ranges as IPv4
+func blockedIPValue(prefix netip.Prefix) string {
+	if prefix.IsSingleIP() {
+		return prefix.Addr().Unmap().String()
+	}
+	if addr := prefix.Addr(); addr.Is4In6() && prefix.Bits() >= 96 {
+		prefix = netip.PrefixFrom(addr.Unmap(), prefix.Bits()-96)
+	}
+	return prefix.String()
+}
@@ -0,0 +1,229 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/venom90/bunnynet-go/common"
+)
+
+// ErrCNAMEMismatch is returned by OnboardHostname when the hostname does not point at the Pull Zone
+var ErrCNAMEMismatch = errors.New("hostname does not point at the pull zone")
+
+// CNAMEResolver looks up the canonical name of a host, *net.Resolver satisfies it
+type CNAMEResolver interface {
+	// LookupCNAME returns the canonical name of the host after following CNAME records
+	LookupCNAME(ctx context.Context, host string) (string, error)
+}
+
+// OnboardStep represents a step of the hostname onboarding workflow
+type OnboardStep string
+
+const (
+	// OnboardStepVerifyDNS checks that the hostname is a CNAME of the Pull Zone
+	OnboardStepVerifyDNS OnboardStep = "verify-dns"
+	// OnboardStepAddHostname adds the hostname to the Pull Zone
+	OnboardStepAddHostname OnboardStep = "add-hostname"
+	// OnboardStepLoadCertificate requests the free certificate for the hostname
+	OnboardStepLoadCertificate OnboardStep = "load-certificate"
+	// OnboardStepForceSSL enables Force SSL for the hostname
+	OnboardStepForceSSL OnboardStep = "force-ssl"
+	// OnboardStepRollback removes the hostname after a failed step
+	OnboardStepRollback OnboardStep = "rollback"
+)
+
+// OnboardOptions represents the options for onboarding a custom hostname
+type OnboardOptions struct {
+	// Resolver looks up the CNAME of the hostname, defaults to net.DefaultResolver
+	Resolver CNAMEResolver
+
+	// SkipDNSCheck skips verifying the CNAME of the hostname
+	SkipDNSCheck bool
+
+	// Certificate configures the retries of the free certificate request, defaults to
+	// every 10 seconds with backoff for up to 5 minutes
+	Certificate *common.WaitOptions
+
+	// SkipForceSSL leaves Force SSL disabled for the hostname
+	SkipForceSSL bool
+
+	// NoRollback keeps the hostname on the Pull Zone when a later step fails
+	NoRollback bool
+
+	// Progress is called after every step
+	Progress func(OnboardStepResult)
+}
+
+// OnboardStepResult represents the outcome of a step of the onboarding workflow
+type OnboardStepResult struct {
+	// Step is the step that ran
+	Step OnboardStep
+
+	// Attempts is the number of attempts made
+	Attempts int
+
+	// Duration is the time the step took
+	Duration time.Duration
+
+	// Skipped indicates that the step was not needed or disabled
+	Skipped bool
+
+	// Detail describes the outcome of the step
+	Detail string
+
+	// Err is the error of a failed step
+	Err error
+}
+
+// OnboardReport represents the result of onboarding a custom hostname
+type OnboardReport struct {
+	// PullZoneId is the ID of the Pull Zone
+	PullZoneId int64
+
+	// Hostname is the onboarded hostname
+	Hostname string
+
+	// CnameDomain is the CNAME target of the Pull Zone
+	CnameDomain string
+
+	// Steps is the list of steps in the order they ran
+	Steps []OnboardStepResult
+
+	// RolledBack indicates that the hostname was removed after a failed step
+	RolledBack bool
+}
+
+// OnboardHostname adds a custom hostname to a Pull Zone: it verifies that the hostname is a CNAME of the
+// zone's CnameDomain, adds the hostname, requests the free certificate with retries until it is issued and
+// enables Force SSL. When a step after adding the hostname fails, the hostname is removed again unless
+// NoRollback is set. A hostname already on the zone is not added or removed.
+//
+// The report is returned even when the workflow fails.
+func (s *PullZoneService) OnboardHostname(ctx context.Context, pullZoneId int64, hostname string, opts *OnboardOptions) (*OnboardReport, error) {
+	if opts == nil {
+		opts = &OnboardOptions{}
+	}
+	hostname = common.NormalizeDomain(hostname)
+	report := &OnboardReport{PullZoneId: pullZoneId, Hostname: hostname}
+
+	zone, err := s.Get(ctx, pullZoneId, false)
+	if err != nil {
+		return report, err
+	}
+	report.CnameDomain = zone.CnameDomain
+
+	run := func(step OnboardStep, fn func(result *OnboardStepResult) error) error {
+		result := OnboardStepResult{Step: step, Attempts: 1}
+		start := time.Now()
+		result.Err = fn(&result)
+		result.Duration = time.Since(start)
+		report.Steps = append(report.Steps, result)
+		if opts.Progress != nil {
+			opts.Progress(result)
+		}
+		if result.Err != nil {
+			return fmt.Errorf("onboard %s: %s: %w", hostname, step, result.Err)
+		}
+		return nil
+	}
+
+	err = run(OnboardStepVerifyDNS, func(result *OnboardStepResult) error {
+		if opts.SkipDNSCheck {
+			result.Skipped = true
+			return nil
+		}
+		return verifyCNAME(ctx, opts.Resolver, hostname, zone.CnameDomain, result)
+	})
+	if err != nil {
+		return report, err
+	}
+
+	added := false
+	err = run(OnboardStepAddHostname, func(result *OnboardStepResult) error {
+		if zone.FindHostname(hostname) != nil {
+			result.Skipped = true
+			result.Detail = "hostname already added"
+			return nil
+		}
+		if err := s.AddHostname(ctx, pullZoneId, AddHostnameOptions{Hostname: hostname}); err != nil {
+			return err
+		}
+		added = true
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	err = run(OnboardStepLoadCertificate, func(result *OnboardStepResult) error {
+		return s.loadCertificateWithRetry(ctx, hostname, opts.Certificate, result)
+	})
+	if err == nil {
+		err = run(OnboardStepForceSSL, func(result *OnboardStepResult) error {
+			if opts.SkipForceSSL {
+				result.Skipped = true
+				return nil
+			}
+			return s.SetForceSSL(ctx, pullZoneId, SetForceSSLOptions{Hostname: hostname, ForceSSL: true})
+		})
+	}
+	if err == nil || !added || opts.NoRollback {
+		return report, err
+	}
+
+	// The rollback runs even when the context was canceled, so that the hostname is not left behind
+	rollbackErr := run(OnboardStepRollback, func(result *OnboardStepResult) error {
+		return s.RemoveHostname(context.WithoutCancel(ctx), pullZoneId, RemoveHostnameOptions{Hostname: hostname})
+	})
+	report.RolledBack = rollbackErr == nil
+
+	return report, errors.Join(err, rollbackErr)
+}
+
+// verifyCNAME checks that the canonical name of the hostname is the CNAME domain of the Pull Zone
+func verifyCNAME(ctx context.Context, resolver CNAMEResolver, hostname, cnameDomain string, result *OnboardStepResult) error {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	cname, err := resolver.LookupCNAME(ctx, hostname)
+	if err != nil {
+		return err
+	}
+
+	cname = common.NormalizeDomain(cname)
+	result.Detail = hostname + " -> " + cname
+	if cname != common.NormalizeDomain(cnameDomain) {
+		return fmt.Errorf("%w: %s resolves to %s instead of %s", ErrCNAMEMismatch, hostname, cname, cnameDomain)
+	}
+	return nil
+}
+
+// loadCertificateWithRetry requests the free certificate until it is issued. API errors, such as the
+// hostname not resolving yet, are retried; other errors stop the retries.
+func (s *PullZoneService) loadCertificateWithRetry(ctx context.Context, hostname string, wait *common.WaitOptions, result *OnboardStepResult) error {
+	if wait == nil {
+		wait = &common.WaitOptions{Interval: 10 * time.Second, Multiplier: 1.5, Timeout: 5 * time.Minute}
+	}
+
+	var lastErr error
+	result.Attempts = 0
+	err := common.Poll(ctx, wait, func(ctx context.Context) (bool, error) {
+		result.Attempts++
+		err := s.LoadFreeCertificate(ctx, LoadFreeCertificateOptions{Hostname: hostname})
+
+		var apiErr *common.ErrorResponse
+		if errors.As(err, &apiErr) {
+			lastErr = err
+			return false, nil
+		}
+		return err == nil, err
+	})
+	if err != nil && lastErr != nil {
+		return fmt.Errorf("%w, last error: %w", err, lastErr)
+	}
+	return err
+}
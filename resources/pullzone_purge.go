@@ -0,0 +1,242 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/venom90/bunnynet-go/common"
+)
+
+// ZonePurgeKind represents the kind of a purge made by PullZoneService.Purge
+type ZonePurgeKind string
+
+const (
+	// ZonePurgeKindZone purges the whole Pull Zone
+	ZonePurgeKindZone ZonePurgeKind = "zone"
+	// ZonePurgeKindTag purges a cache tag
+	ZonePurgeKindTag ZonePurgeKind = "tag"
+	// ZonePurgeKindURL purges a URL on one of the zone's hostnames
+	ZonePurgeKindURL ZonePurgeKind = "url"
+)
+
+// ZonePurgeOptions represents what PullZoneService.Purge purges
+type ZonePurgeOptions struct {
+	// All purges the whole Pull Zone, Tags and Paths are then ignored
+	All bool
+
+	// Tags is the list of cache tags to purge
+	Tags []string
+
+	// Paths is the list of paths to purge on every hostname of the zone, a trailing "*" purges every path under the prefix
+	Paths []string
+
+	// IncludeSystemHostnames also purges Paths on the b-cdn.net hostnames managed by bunny.net
+	IncludeSystemHostnames bool
+
+	// Wait makes each URL purge wait until the purge has completed. Tag and zone purges have no
+	// completion status and return once the API accepts them.
+	Wait bool
+
+	// Concurrency is the maximum number of concurrent requests, defaults to 8
+	Concurrency int
+}
+
+// ZonePurgeResult represents the outcome of a single purge made by PullZoneService.Purge
+type ZonePurgeResult struct {
+	// Kind is the kind of purge
+	Kind ZonePurgeKind
+
+	// Target is the cache tag or URL that was purged, empty for zone purges
+	Target string
+
+	// Err is the error from the purge, nil on success
+	Err error
+}
+
+// ZonePurgeReport represents the result of PullZoneService.Purge
+type ZonePurgeReport struct {
+	// PullZoneId is the ID of the purged Pull Zone
+	PullZoneId int64
+
+	// Results is the outcome of every purge
+	Results []ZonePurgeResult
+}
+
+// Failed returns the purges that failed
+func (r *ZonePurgeReport) Failed() []ZonePurgeResult {
+	var failed []ZonePurgeResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Purge purges the whole Pull Zone, or its cache tags and paths. Paths are purged on every hostname
+// of the zone through PurgeService.PurgeMany. An error is returned without purging anything when
+// Paths are set and the zone has no hostname to purge them on.
+//
+// The report is returned even when some purges fail; the error then joins the errors of all failed purges.
+func (s *PullZoneService) Purge(ctx context.Context, id int64, opts *ZonePurgeOptions) (*ZonePurgeReport, error) {
+	if opts == nil || (!opts.All && len(opts.Tags) == 0 && len(opts.Paths) == 0) {
+		return nil, errors.New("nothing to purge, set All, Tags or Paths")
+	}
+
+	report := &ZonePurgeReport{PullZoneId: id}
+	if opts.All {
+		err := s.PurgeCache(ctx, id, &PurgeCacheOptions{})
+		report.Results = append(report.Results, ZonePurgeResult{Kind: ZonePurgeKindZone, Err: err})
+		return report, err
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultPurgeConcurrency
+	}
+
+	// The URLs are built before anything is purged, so that a zone without hostnames to purge
+	// paths on fails without purging its tags
+	var urls []string
+	if len(opts.Paths) > 0 {
+		zone, err := s.Get(ctx, id, false)
+		if err != nil {
+			return report, err
+		}
+
+		for _, hostname := range zone.Hostnames {
+			if hostname.IsSystemHostname && !opts.IncludeSystemHostnames {
+				continue
+			}
+			for _, p := range opts.Paths {
+				urls = append(urls, zonePurgeURL(hostname.Value, p))
+			}
+		}
+		if len(urls) == 0 {
+			return report, fmt.Errorf("pull zone %d has no custom hostnames to purge paths on, set IncludeSystemHostnames to purge the system hostnames", id)
+		}
+	}
+
+	report.Results = append(report.Results, s.purgeTags(ctx, id, opts.Tags, concurrency)...)
+	errs := joinZonePurgeErrors(report.Results)
+
+	if len(urls) > 0 {
+		purge := NewPurgeService(s.client, s.baseURL, s.apiKey, s.userAgent)
+		purged, err := purge.PurgeMany(ctx, urls, &PurgeManyOptions{Async: !opts.Wait, Concurrency: concurrency})
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		failures := map[string]error{}
+		for _, result := range purged.Results {
+			if result.Err != nil {
+				failures[result.PurgedAs] = result.Err
+				if result.PurgedAs == "" {
+					report.Results = append(report.Results, ZonePurgeResult{Kind: ZonePurgeKindURL, Target: result.URL, Err: result.Err})
+				}
+			}
+		}
+		for _, target := range purged.Purged {
+			report.Results = append(report.Results, ZonePurgeResult{Kind: ZonePurgeKindURL, Target: target, Err: failures[target]})
+		}
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// PurgeByRef resolves a Pull Zone by ID, name or hostname and purges it
+func (s *PullZoneService) PurgeByRef(ctx context.Context, ref string, opts *ZonePurgeOptions) (*ZonePurgeReport, error) {
+	zone, err := s.Resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.Purge(ctx, zone.Id, opts)
+}
+
+// Resolve returns the Pull Zone identified by a reference, which is a numeric ID, the name of the
+// zone or one of its hostnames. Names and hostnames are compared case-insensitively.
+// An error matching common.ErrNotFound is returned when no zone matches.
+func (s *PullZoneService) Resolve(ctx context.Context, ref string) (*PullZone, error) {
+	ref = strings.TrimSpace(ref)
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		return s.Get(ctx, id, false)
+	}
+
+	zones, err := s.ListAll(ctx, common.MaxPerPage, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range zones {
+		if strings.EqualFold(zones[i].Name, ref) {
+			return &zones[i], nil
+		}
+	}
+	for i := range zones {
+		if zones[i].FindHostname(ref) != nil {
+			return &zones[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("pull zone %q: %w", ref, common.ErrNotFound)
+}
+
+// purgeTags purges the cache tags with bounded concurrency
+func (s *PullZoneService) purgeTags(ctx context.Context, id int64, tags []string, concurrency int) []ZonePurgeResult {
+	results := make([]ZonePurgeResult, 0, len(tags))
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" && !seen[tag] {
+			seen[tag] = true
+			results = append(results, ZonePurgeResult{Kind: ZonePurgeKindTag, Target: tag})
+		}
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range results {
+		result := &results[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+				result.Err = s.PurgeCache(ctx, id, &PurgeCacheOptions{CacheTag: result.Target})
+			case <-ctx.Done():
+				result.Err = ctx.Err()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// zonePurgeURL builds the URL of a path on a hostname, it is normalized by PurgeMany
+func zonePurgeURL(hostname, p string) string {
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return "https://" + hostname + p
+}
+
+// joinZonePurgeErrors returns the errors of the failed purges
+func joinZonePurgeErrors(results []ZonePurgeResult) []error {
+	var errs []error
+	for _, result := range results {
+		if result.Err == nil {
+			continue
+		}
+		if result.Target == "" {
+			errs = append(errs, result.Err)
+		} else {
+			errs = append(errs, fmt.Errorf("%s %s: %w", result.Kind, result.Target, result.Err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,265 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultPurgeConcurrency is the number of concurrent purge requests used by PurgeMany when none is set
+const defaultPurgeConcurrency = 8
+
+// PurgeManyOptions represents the options for purging many URLs
+type PurgeManyOptions struct {
+	// Async returns from each purge request without waiting for the purge to complete
+	Async bool
+
+	// Concurrency is the maximum number of concurrent purge requests, defaults to 8
+	Concurrency int
+
+	// RequestsPerSecond limits the rate of purge requests, 0 sends them as fast as Concurrency allows.
+	// The client has no global rate limit, so the limit only applies to this call.
+	RequestsPerSecond float64
+
+	// WildcardThreshold collapses the URLs of a directory into a single wildcard purge of the
+	// directory when at least this many URLs share it, 0 never collapses
+	WildcardThreshold int
+}
+
+// PurgeURLResult represents the outcome of purging a single URL passed to PurgeMany
+type PurgeURLResult struct {
+	// URL is the URL as passed to PurgeMany
+	URL string
+
+	// PurgedAs is the URL that was purged, either the normalized URL or a wildcard covering it
+	PurgedAs string
+
+	// Err is the error from normalizing or purging the URL
+	Err error
+}
+
+// PurgeReport represents the result of purging many URLs
+type PurgeReport struct {
+	// Results is the outcome of every URL, in the order they were passed
+	Results []PurgeURLResult
+
+	// Purged is the list of URLs sent to the API after deduplication and collapsing
+	Purged []string
+}
+
+// Failed returns the results of the URLs that could not be purged
+func (r *PurgeReport) Failed() []PurgeURLResult {
+	var failed []PurgeURLResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// PurgeMany purges many URLs. URLs are normalized and deduplicated, URLs covered by a wildcard URL in
+// the list are dropped and, when WildcardThreshold is set, crowded directories are collapsed into a
+// wildcard purge. Purges run with bounded concurrency and an optional rate limit.
+//
+// The report is returned even when some purges fail; the error then joins the errors of all failed purges.
+func (s *PurgeService) PurgeMany(ctx context.Context, urls []string, opts *PurgeManyOptions) (*PurgeReport, error) {
+	if opts == nil {
+		opts = &PurgeManyOptions{}
+	}
+
+	report := &PurgeReport{Results: make([]PurgeURLResult, len(urls))}
+	normalized := make([]string, len(urls))
+	for i, raw := range urls {
+		report.Results[i].URL = raw
+		normalized[i], report.Results[i].Err = NormalizePurgeURL(raw)
+	}
+
+	targets := planPurge(normalized, report.Results, opts.WildcardThreshold)
+	unique := map[string]bool{}
+	for _, target := range targets {
+		if !unique[target] {
+			unique[target] = true
+			report.Purged = append(report.Purged, target)
+		}
+	}
+	sort.Strings(report.Purged)
+
+	errs := s.purgeAll(ctx, report.Purged, opts)
+
+	var joined []error
+	for i := range report.Results {
+		result := &report.Results[i]
+		if result.Err != nil {
+			joined = append(joined, fmt.Errorf("%s: %w", result.URL, result.Err))
+			continue
+		}
+		result.PurgedAs = targets[normalized[i]]
+		result.Err = errs[result.PurgedAs]
+	}
+	for _, target := range report.Purged {
+		if err := errs[target]; err != nil {
+			joined = append(joined, fmt.Errorf("%s: %w", target, err))
+		}
+	}
+
+	return report, errors.Join(joined...)
+}
+
+// NormalizePurgeURL normalizes a URL for purging: the scheme and host are lowercased, default ports
+// and fragments are removed and an empty path becomes "/". A trailing "*" marks a wildcard purge.
+func NormalizePurgeURL(raw string) (string, error) {
+	// The wildcard is kept out of parsing, since it would be escaped along with other characters of the path
+	trimmed := strings.TrimSpace(raw)
+	wildcard := ""
+	if strings.HasSuffix(trimmed, "*") {
+		trimmed, wildcard = strings.TrimSuffix(trimmed, "*"), "*"
+	}
+
+	u, err := url.Parse(trimmed)
+	if err != nil {
+		return "", err
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("URL %q must use http or https", raw)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("URL %q has no host", raw)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && !(u.Scheme == "http" && port == "80") && !(u.Scheme == "https" && port == "443") {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	u.Host = host
+	u.Fragment = ""
+	u.RawFragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+
+	return u.String() + wildcard, nil
+}
+
+// planPurge maps every normalized URL to the URL that purges it
+func planPurge(normalized []string, results []PurgeURLResult, threshold int) map[string]string {
+	var wildcards []string
+	for i, u := range normalized {
+		if results[i].Err == nil && strings.HasSuffix(u, "*") {
+			wildcards = append(wildcards, u)
+		}
+	}
+
+	targets := map[string]string{}
+	directories := map[string][]string{}
+	for i, u := range normalized {
+		if results[i].Err != nil || targets[u] != "" {
+			continue
+		}
+		if wildcard := coveringWildcard(u, wildcards); wildcard != "" {
+			targets[u] = wildcard
+			continue
+		}
+		targets[u] = u
+
+		if threshold > 0 && !strings.HasSuffix(u, "*") {
+			directory := purgeDirectory(u)
+			directories[directory] = append(directories[directory], u)
+		}
+	}
+
+	for directory, members := range directories {
+		if len(members) < threshold {
+			continue
+		}
+		for _, u := range members {
+			targets[u] = directory + "*"
+		}
+	}
+
+	return targets
+}
+
+// coveringWildcard returns the broadest wildcard URL covering the URL, excluding the URL itself
+func coveringWildcard(u string, wildcards []string) string {
+	best := ""
+	for _, wildcard := range wildcards {
+		if wildcard == u {
+			continue
+		}
+		if strings.HasPrefix(u, strings.TrimSuffix(wildcard, "*")) && (best == "" || len(wildcard) < len(best)) {
+			best = wildcard
+		}
+	}
+	return best
+}
+
+// purgeDirectory returns the URL of the directory containing the URL, ending with a slash
+func purgeDirectory(u string) string {
+	if i := strings.IndexByte(u, '?'); i >= 0 {
+		u = u[:i]
+	}
+	return u[:strings.LastIndex(u, "/")+1]
+}
+
+// purgeAll purges the URLs with bounded concurrency and an optional rate limit, returning the errors by URL
+func (s *PurgeService) purgeAll(ctx context.Context, urls []string, opts *PurgeManyOptions) map[string]error {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultPurgeConcurrency
+	}
+
+	var ticker *time.Ticker
+	if opts.RequestsPerSecond > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / opts.RequestsPerSecond))
+		defer ticker.Stop()
+	}
+
+	errs := make(map[string]error, len(urls))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for i, u := range urls {
+		// The first request is sent immediately, later ones wait for the rate limit
+		if ticker != nil && i > 0 {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+			}
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			errs[u] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := s.Purge(ctx, u, opts.Async)
+			mu.Lock()
+			errs[u] = err
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	return errs
+}
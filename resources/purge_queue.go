@@ -0,0 +1,285 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultPurgeQueueWindow is the coalescing window used by PurgeQueue when none is set
+	defaultPurgeQueueWindow = 2 * time.Second
+
+	// defaultPurgeQueueConcurrency is the number of concurrent requests used by PurgeQueue when none is set
+	defaultPurgeQueueConcurrency = 4
+)
+
+// ErrPurgeQueueClosed is returned when adding to a PurgeQueue after Close
+var ErrPurgeQueueClosed = errors.New("purge queue is closed")
+
+// PurgeQueueOptions represents the options of a PurgeQueue
+type PurgeQueueOptions struct {
+	// Window is the time items are collected before they are purged, duplicates within it are purged once.
+	// Defaults to 2 seconds.
+	Window time.Duration
+
+	// Concurrency is the maximum number of concurrent purge requests, defaults to 4
+	Concurrency int
+
+	// Async returns from URL purge requests without waiting for the purge to complete
+	Async bool
+
+	// OnError is called for every item that could not be purged
+	OnError func(item PurgeItem, err error)
+}
+
+// PurgeItem represents a URL or a cache tag of a Pull Zone waiting to be purged
+type PurgeItem struct {
+	// URL is the normalized URL to purge, empty for cache tags
+	URL string
+
+	// PullZoneId is the ID of the Pull Zone of the cache tag
+	PullZoneId int64
+
+	// CacheTag is the cache tag to purge, empty for URLs
+	CacheTag string
+}
+
+// String returns the URL or the Pull Zone and cache tag of the item
+func (i PurgeItem) String() string {
+	if i.URL != "" {
+		return i.URL
+	}
+	return fmt.Sprintf("pullzone %d tag %s", i.PullZoneId, i.CacheTag)
+}
+
+// PurgeQueueStats represents the counters of a PurgeQueue
+type PurgeQueueStats struct {
+	// Queued is the number of items added, including duplicates
+	Queued int64
+
+	// Coalesced is the number of added items that were already waiting and will be purged once
+	Coalesced int64
+
+	// Purged is the number of items purged successfully
+	Purged int64
+
+	// Failed is the number of items that could not be purged
+	Failed int64
+
+	// Pending is the number of items waiting for the window to end
+	Pending int
+
+	// InFlight is the number of items being purged
+	InFlight int
+}
+
+// PurgeQueue collects URLs and cache tags from many goroutines and purges them in batches, so that
+// items added repeatedly within the window are purged once. It is safe for concurrent use.
+type PurgeQueue struct {
+	purge     *PurgeService
+	pullZones *PullZoneService
+	opts      PurgeQueueOptions
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	pending map[PurgeItem]bool
+	timer   *time.Timer
+	closed  bool
+	stats   PurgeQueueStats
+}
+
+// NewPurgeQueue creates a PurgeQueue. The Pull Zone service is only needed for cache tags and may be nil otherwise.
+func NewPurgeQueue(purge *PurgeService, pullZones *PullZoneService, opts *PurgeQueueOptions) *PurgeQueue {
+	q := &PurgeQueue{purge: purge, pullZones: pullZones, pending: map[PurgeItem]bool{}}
+	if opts != nil {
+		q.opts = *opts
+	}
+	if q.opts.Window <= 0 {
+		q.opts.Window = defaultPurgeQueueWindow
+	}
+	if q.opts.Concurrency <= 0 {
+		q.opts.Concurrency = defaultPurgeQueueConcurrency
+	}
+	q.sem = make(chan struct{}, q.opts.Concurrency)
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+	return q
+}
+
+// PurgeURL queues a URL for purging
+func (q *PurgeQueue) PurgeURL(url string) error {
+	normalized, err := NormalizePurgeURL(url)
+	if err != nil {
+		return err
+	}
+	return q.add(PurgeItem{URL: normalized})
+}
+
+// PurgeTag queues a cache tag of a Pull Zone for purging
+func (q *PurgeQueue) PurgeTag(pullZoneId int64, cacheTag string) error {
+	if q.pullZones == nil {
+		return errors.New("purge queue has no pull zone service for cache tags")
+	}
+	if cacheTag == "" {
+		return errors.New("cache tag is required")
+	}
+	return q.add(PurgeItem{PullZoneId: pullZoneId, CacheTag: cacheTag})
+}
+
+// Stats returns the current counters of the queue
+func (q *PurgeQueue) Stats() PurgeQueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	stats := q.stats
+	stats.Pending = len(q.pending)
+	return stats
+}
+
+// Flush purges the waiting items without waiting for the window to end and returns the errors
+// of the items it purged
+func (q *PurgeQueue) Flush(ctx context.Context) error {
+	items := q.take()
+	return q.purgeItems(ctx, items)
+}
+
+// Close stops accepting items, purges the waiting items and waits for all purges to finish.
+// When the context ends first, the remaining purges are canceled and the context error is returned.
+func (q *PurgeQueue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+
+	items := q.take()
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		q.purgeItems(q.ctx, items)
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.cancel()
+		return nil
+	case <-ctx.Done():
+		q.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// add queues an item, starting the window when the queue was empty
+func (q *PurgeQueue) add(item PurgeItem) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrPurgeQueueClosed
+	}
+
+	q.stats.Queued++
+	if q.pending[item] {
+		q.stats.Coalesced++
+		return nil
+	}
+	q.pending[item] = true
+
+	if q.timer == nil {
+		// The pending flush is counted so that Close waits for a window that already ended
+		q.wg.Add(1)
+		q.timer = time.AfterFunc(q.opts.Window, q.flushWindow)
+	}
+	return nil
+}
+
+// flushWindow purges the waiting items when the window ends
+func (q *PurgeQueue) flushWindow() {
+	defer q.wg.Done()
+
+	q.purgeItems(q.ctx, q.take())
+}
+
+// take removes and returns the waiting items and stops the window
+func (q *PurgeQueue) take() []PurgeItem {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.timer != nil {
+		if q.timer.Stop() {
+			q.wg.Done()
+		}
+		q.timer = nil
+	}
+
+	items := make([]PurgeItem, 0, len(q.pending))
+	for item := range q.pending {
+		items = append(items, item)
+	}
+	q.pending = map[PurgeItem]bool{}
+	q.stats.InFlight += len(items)
+	return items
+}
+
+// purgeItems purges the items with bounded concurrency and records the outcome of each
+func (q *PurgeQueue) purgeItems(ctx context.Context, items []PurgeItem) error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+
+	for _, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var err error
+			select {
+			case q.sem <- struct{}{}:
+				err = q.purgeItem(ctx, item)
+				<-q.sem
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+
+			q.mu.Lock()
+			q.stats.InFlight--
+			if err != nil {
+				q.stats.Failed++
+			} else {
+				q.stats.Purged++
+			}
+			q.mu.Unlock()
+
+			if err != nil {
+				if q.opts.OnError != nil {
+					q.opts.OnError(item, err)
+				}
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", item, err))
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// purgeItem sends the purge request of a single item
+func (q *PurgeQueue) purgeItem(ctx context.Context, item PurgeItem) error {
+	if item.URL != "" {
+		return q.purge.Purge(ctx, item.URL, q.opts.Async)
+	}
+	return q.pullZones.PurgeCache(ctx, item.PullZoneId, &PurgeCacheOptions{CacheTag: item.CacheTag})
+}
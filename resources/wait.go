@@ -0,0 +1,77 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/venom90/bunnynet-go/common"
+)
+
+// WaitForDNSZone polls a DNS zone until the condition returns true and returns the zone in that state.
+// The last zone fetched is returned together with the error when waiting fails.
+func (s *DNSZoneService) WaitForDNSZone(ctx context.Context, zoneId int64, opts *common.WaitOptions, condition func(*DNSZone) bool) (*DNSZone, error) {
+	var zone *DNSZone
+	err := common.Poll(ctx, opts, func(ctx context.Context) (bool, error) {
+		current, err := s.Get(ctx, zoneId)
+		if err != nil {
+			return false, err
+		}
+		zone = current
+		return condition(zone), nil
+	})
+	return zone, err
+}
+
+// WaitForNameservers polls a DNS zone every interval until its nameservers are detected
+func (s *DNSZoneService) WaitForNameservers(ctx context.Context, zoneId int64, interval time.Duration) (*DNSZone, error) {
+	return s.WaitForDNSZone(ctx, zoneId, &common.WaitOptions{Interval: interval}, func(zone *DNSZone) bool {
+		return zone.NameserversDetected
+	})
+}
+
+// WaitForPullZone polls a Pull Zone until the condition returns true and returns the zone in that state.
+// The last zone fetched is returned together with the error when waiting fails.
+func (s *PullZoneService) WaitForPullZone(ctx context.Context, id int64, opts *common.WaitOptions, condition func(*PullZone) bool) (*PullZone, error) {
+	var zone *PullZone
+	err := common.Poll(ctx, opts, func(ctx context.Context) (bool, error) {
+		current, err := s.Get(ctx, id, false)
+		if err != nil {
+			return false, err
+		}
+		zone = current
+		return condition(zone), nil
+	})
+	return zone, err
+}
+
+// WaitForCertificate polls a Pull Zone until the hostname has a certificate, such as after LoadFreeCertificate.
+// It fails with an error matching common.ErrNotFound when the hostname is not added to the zone.
+func (s *PullZoneService) WaitForCertificate(ctx context.Context, id int64, hostname string, opts *common.WaitOptions) (*Hostname, error) {
+	var found *Hostname
+	var missing bool
+	_, err := s.WaitForPullZone(ctx, id, opts, func(zone *PullZone) bool {
+		found = zone.FindHostname(hostname)
+		missing = found == nil
+		return missing || found.HasCertificate
+	})
+	if err != nil {
+		return found, err
+	}
+	if missing {
+		return nil, fmt.Errorf("hostname %s on pull zone %d: %w", hostname, id, common.ErrNotFound)
+	}
+	return found, nil
+}
+
+// FindHostname returns the hostname of the zone with the given value, compared case-insensitively, or nil
+func (z *PullZone) FindHostname(value string) *Hostname {
+	value = strings.TrimSuffix(value, ".")
+	for i := range z.Hostnames {
+		if strings.EqualFold(z.Hostnames[i].Value, value) {
+			return &z.Hostnames[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/backup"
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+const archivedZone = `{
+	"Id": 5,
+	"Name": "site",
+	"OriginUrl": "https://origin.example.com",
+	"EnableWebPVary": true,
+	"BlockedIps": ["192.0.2.1"],
+	"Hostnames": [
+		{"Value": "site.b-cdn.net", "IsSystemHostname": true},
+		{"Value": "cdn.example.com", "ForceSSL": true, "HasCertificate": true, "Certificate": "Y2VydA==", "CertificateKey": "a2V5"}
+	],
+	"EdgeRules": [
+		{"Guid": "rule-1", "ActionType": 5, "ActionParameter1": "X-Frame-Options", "ActionParameter2": "DENY", "Enabled": true, "Triggers": [{"Type": 0, "PatternMatches": ["*"]}]},
+		{"Guid": "rule-2", "ActionType": 4, "Description": "Block admin", "Enabled": true, "Triggers": [{"Type": 0, "PatternMatches": ["*"]}]}
+	]
+}`
+
+// recorder records the requests made while restoring
+type recorder struct {
+	mu       sync.Mutex
+	requests []string
+	update   map[string]interface{}
+}
+
+func (r *recorder) add(request string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, request)
+}
+
+func archived(t *testing.T) *resources.PullZone {
+	var zone resources.PullZone
+	require.NoError(t, json.Unmarshal([]byte(archivedZone), &zone))
+	return &zone
+}
+
+func TestExport_RoundTrip(t *testing.T) {
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /pullzone/5": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "true", r.URL.Query().Get("includeCertificate"))
+			test.RespondJSON(w, http.StatusOK, archivedZone)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	archive, err := backup.Export(context.Background(), client.PullZone, []int64{5}, &backup.ExportOptions{Certificates: true})
+	require.NoError(t, err)
+	assert.Equal(t, backup.Version, archive.Version)
+	assert.True(t, archive.Certificates)
+
+	path := filepath.Join(t.TempDir(), "backup.json")
+	require.NoError(t, archive.Save(path))
+
+	loaded, err := backup.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, archive.PullZones, loaded.PullZones)
+	assert.True(t, archive.CreatedAt.Equal(loaded.CreatedAt))
+
+	zone, err := loaded.Find("SITE")
+	require.NoError(t, err)
+	assert.Equal(t, "a2V5", zone.Hostnames[1].CertificateKey)
+
+	_, err = loaded.Find("7")
+	assert.True(t, errors.Is(err, common.ErrNotFound))
+}
+
+func TestRead_Version(t *testing.T) {
+	_, err := backup.Read(bytes.NewBufferString(`{"PullZones": []}`))
+	assert.ErrorContains(t, err, "missing Version")
+
+	_, err = backup.Read(bytes.NewBufferString(`{"Version": 99}`))
+	assert.True(t, errors.Is(err, backup.ErrUnsupportedVersion))
+}
+
+func TestRestore_Existing(t *testing.T) {
+	rec := &recorder{}
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /pullzone/5": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{
+				"Id": 5,
+				"Name": "site",
+				"OriginUrl": "https://origin.example.com",
+				"EnableWebPVary": false,
+				"BlockedIps": ["192.0.2.1"],
+				"Hostnames": [{"Value": "site.b-cdn.net", "IsSystemHostname": true}],
+				"EdgeRules": [
+					{"Guid": "rule-1", "ActionType": 5, "ActionParameter1": "X-Frame-Options", "ActionParameter2": "SAMEORIGIN", "Enabled": true, "Triggers": [{"Type": 0, "PatternMatches": ["*"]}]},
+					{"Guid": "rule-2", "ActionType": 4, "Description": "Block admin", "Enabled": true, "Triggers": [{"Type": 0, "PatternMatches": ["*"]}]},
+					{"Guid": "rule-3", "ActionType": 0, "Enabled": true, "Triggers": [{"Type": 0, "PatternMatches": ["*"]}]}
+				]
+			}`)
+		},
+		"POST /pullzone/5": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&rec.update)
+			test.RespondJSON(w, http.StatusOK, `{"Id": 5}`)
+		},
+		"POST /pullzone/5/edgerules/addOrUpdate": func(w http.ResponseWriter, r *http.Request) {
+			var options resources.AddOrUpdateEdgeRuleOptions
+			json.NewDecoder(r.Body).Decode(&options)
+			rec.add("edge rule " + options.Guid + " " + options.ActionParameter2)
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"DELETE /pullzone/5/edgerules/rule-3": func(w http.ResponseWriter, r *http.Request) {
+			rec.add("delete rule-3")
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"POST /pullzone/5/addHostname": func(w http.ResponseWriter, r *http.Request) {
+			rec.add("hostname")
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"POST /pullzone/5/addCertificate": func(w http.ResponseWriter, r *http.Request) {
+			var options resources.AddCertificateOptions
+			json.NewDecoder(r.Body).Decode(&options)
+			assert.Equal(t, "Y2VydA==", options.Certificate)
+			rec.add("certificate")
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"POST /pullzone/5/setForceSSL": func(w http.ResponseWriter, r *http.Request) {
+			rec.add("force ssl")
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	report, err := backup.Restore(context.Background(), client.PullZone, archived(t), &backup.RestoreOptions{
+		Hostnames:    true,
+		Certificates: true,
+	})
+	require.NoError(t, err)
+
+	assert.False(t, report.Created)
+	assert.Equal(t, map[string]interface{}{"EnableWebPVary": true}, rec.update)
+	assert.Equal(t, 1, report.Settings)
+	assert.Equal(t, 1, report.EdgeRules)
+	assert.Equal(t, 1, report.DeletedEdgeRules)
+	assert.Equal(t, []string{"cdn.example.com"}, report.Hostnames)
+	assert.Equal(t, []string{"cdn.example.com"}, report.Certificates)
+	assert.Equal(t, []string{
+		"edge rule rule-1 DENY",
+		"delete rule-3",
+		"hostname",
+		"certificate",
+		"force ssl",
+	}, rec.requests)
+}
+
+func TestRestore_Deleted(t *testing.T) {
+	rec := &recorder{}
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /pullzone/5": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusNotFound, `{"ErrorKey": "pullzone.not_found", "Message": "The requested Pull Zone was not found"}`)
+		},
+		"POST /pullzone": func(w http.ResponseWriter, r *http.Request) {
+			var options resources.AddPullZoneOptions
+			json.NewDecoder(r.Body).Decode(&options)
+			rec.add("create " + options.Name)
+			test.RespondJSON(w, http.StatusCreated, `{"Id": 9, "Name": "site", "OriginUrl": "https://origin.example.com"}`)
+		},
+		"POST /pullzone/9": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&rec.update)
+			test.RespondJSON(w, http.StatusOK, `{"Id": 9}`)
+		},
+		"POST /pullzone/9/edgerules/addOrUpdate": func(w http.ResponseWriter, r *http.Request) {
+			rec.add("edge rule")
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"POST /pullzone/9/addHostname": func(w http.ResponseWriter, r *http.Request) {
+			rec.add("hostname")
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"POST /pullzone/9/setForceSSL": func(w http.ResponseWriter, r *http.Request) {
+			rec.add("force ssl")
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"POST /pullzone/9/addCertificate": func(w http.ResponseWriter, r *http.Request) {
+			rec.add("certificate")
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"GET /pullzone/9": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{"Id": 9, "Name": "site"}`)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	report, err := backup.Restore(context.Background(), client.PullZone, archived(t), &backup.RestoreOptions{
+		Hostnames:    true,
+		Certificates: true,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, report.Created)
+	assert.Equal(t, int64(9), report.PullZone.Id)
+	assert.Equal(t, 2, report.EdgeRules)
+	assert.Equal(t, []string{"cdn.example.com"}, report.Certificates)
+	assert.Empty(t, report.Skipped)
+	assert.Equal(t, true, rec.update["EnableWebPVary"])
+	assert.Equal(t, []string{
+		"create site",
+		"edge rule",
+		"edge rule",
+		"hostname",
+		"force ssl",
+		"certificate",
+	}, rec.requests)
+}
+
+func TestRestore_Deleted_SettingsRejected(t *testing.T) {
+	rec := &recorder{}
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /pullzone/5": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusNotFound, `{"ErrorKey": "pullzone.not_found", "Message": "The requested Pull Zone was not found"}`)
+		},
+		"POST /pullzone": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusCreated, `{"Id": 9, "Name": "site", "OriginUrl": "https://origin.example.com"}`)
+		},
+		"POST /pullzone/9": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusBadRequest, `{"ErrorKey": "pullzone.validation", "Message": "Invalid setting"}`)
+		},
+		"POST /pullzone/9/edgerules/addOrUpdate": func(w http.ResponseWriter, r *http.Request) {
+			rec.add("edge rule")
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"GET /pullzone/9": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{"Id": 9, "Name": "site"}`)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	// The zone is recreated with its edge rules even though its settings were rejected
+	report, err := backup.Restore(context.Background(), client.PullZone, archived(t), nil)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "Settings")
+
+	require.NotNil(t, report)
+	assert.True(t, report.Created)
+	assert.Equal(t, 2, report.EdgeRules)
+	assert.Equal(t, []string{"edge rule", "edge rule"}, rec.requests)
+	require.Len(t, report.Skipped, 1)
+	assert.Equal(t, "Settings", report.Skipped[0].Setting)
+}
@@ -0,0 +1,198 @@
+package certificate
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/certificate"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+// testCA is a certificate authority for issuing test certificates
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a PEM encoded certificate for the names and its PKCS #8 key
+func (ca *testCA) issue(t *testing.T, notAfter time.Time, names ...string) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: names[0]},
+		DNSNames:     names,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestParse_Validate(t *testing.T) {
+	ca := newTestCA(t)
+	certPEM, keyPEM := ca.issue(t, time.Now().Add(30*24*time.Hour), "cdn.example.com", "*.static.example.com")
+	chain := append(append([]byte{}, certPEM...), ca.pem...)
+
+	bundle, err := certificate.Parse(chain, keyPEM)
+	require.NoError(t, err)
+	assert.Equal(t, "cdn.example.com", bundle.Leaf.Subject.CommonName)
+	require.Len(t, bundle.Intermediates, 1)
+
+	now := time.Now()
+	assert.NoError(t, bundle.Validate("cdn.example.com", now))
+	assert.NoError(t, bundle.Validate("img.static.example.com", now))
+	assert.InDelta(t, (30 * 24 * time.Hour).Hours(), bundle.ExpiresIn(now).Hours(), 1)
+
+	assert.ErrorIs(t, bundle.Validate("www.example.com", now), certificate.ErrHostnameMismatch)
+	assert.ErrorIs(t, bundle.Validate("cdn.example.com", now.Add(60*24*time.Hour)), certificate.ErrExpired)
+	assert.ErrorIs(t, bundle.Validate("cdn.example.com", now.Add(-24*time.Hour)), certificate.ErrNotYetValid)
+
+	// All problems are reported together
+	err = bundle.Validate("www.example.com", now.Add(60*24*time.Hour))
+	assert.ErrorIs(t, err, certificate.ErrHostnameMismatch)
+	assert.ErrorIs(t, err, certificate.ErrExpired)
+}
+
+func TestParse_Errors(t *testing.T) {
+	ca := newTestCA(t)
+	certPEM, keyPEM := ca.issue(t, time.Now().Add(24*time.Hour), "cdn.example.com")
+	_, otherKeyPEM := ca.issue(t, time.Now().Add(24*time.Hour), "other.example.com")
+
+	_, err := certificate.Parse(certPEM, otherKeyPEM)
+	assert.ErrorIs(t, err, certificate.ErrKeyMismatch)
+
+	_, err = certificate.Parse([]byte("not a certificate"), keyPEM)
+	assert.Error(t, err)
+
+	_, err = certificate.Parse(certPEM, []byte("not a key"))
+	assert.Error(t, err)
+
+	// A chain with the issuer of another CA is out of order
+	other := newTestCA(t)
+	bundle, err := certificate.Parse(append(append([]byte{}, certPEM...), other.pem...), keyPEM)
+	require.NoError(t, err)
+	assert.ErrorIs(t, bundle.Validate("cdn.example.com", time.Now()), certificate.ErrInvalidChain)
+}
+
+func TestUpload(t *testing.T) {
+	ca := newTestCA(t)
+	certPEM, keyPEM := ca.issue(t, time.Now().Add(24*time.Hour), "cdn.example.com")
+
+	var received resources.AddCertificateOptions
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"POST /pullzone/5/addCertificate": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	_, err := certificate.Upload(context.Background(), client.PullZone, 5, "cdn.example.com", certPEM, keyPEM)
+	require.NoError(t, err)
+	assert.Equal(t, "cdn.example.com", received.Hostname)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(certPEM), received.Certificate)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(keyPEM), received.CertificateKey)
+
+	// Invalid certificates are rejected before the request
+	received = resources.AddCertificateOptions{}
+	_, err = certificate.Upload(context.Background(), client.PullZone, 5, "www.example.com", certPEM, keyPEM)
+	assert.ErrorIs(t, err, certificate.ErrHostnameMismatch)
+	assert.Empty(t, received.Hostname)
+}
+
+func TestScanExpiring(t *testing.T) {
+	ca := newTestCA(t)
+	soon, _ := ca.issue(t, time.Now().Add(5*24*time.Hour), "soon.example.com")
+	later, _ := ca.issue(t, time.Now().Add(90*24*time.Hour), "later.example.com")
+	sooner, _ := ca.issue(t, time.Now().Add(2*24*time.Hour), "sooner.example.com")
+
+	block, _ := pem.Decode(sooner)
+	encode := func(data []byte) string { return base64.StdEncoding.EncodeToString(data) }
+
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /pullzone": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "true", r.URL.Query().Get("includeCertificate"))
+			test.RespondJSON(w, http.StatusOK, fmt.Sprintf(`{
+				"Items": [
+					{"Id": 1, "Name": "site", "Hostnames": [
+						{"Value": "site.b-cdn.net", "IsSystemHostname": true, "HasCertificate": true},
+						{"Value": "soon.example.com", "HasCertificate": true, "Certificate": %q},
+						{"Value": "later.example.com", "HasCertificate": true, "Certificate": %q},
+						{"Value": "plain.example.com", "HasCertificate": false}
+					]},
+					{"Id": 2, "Name": "assets", "Hostnames": [
+						{"Value": "sooner.example.com", "HasCertificate": true, "Certificate": %q},
+						{"Value": "broken.example.com", "HasCertificate": true, "Certificate": "bm90IGEgY2VydGlmaWNhdGU="}
+					]}
+				],
+				"CurrentPage": 1,
+				"TotalItems": 2,
+				"HasMoreItems": false
+			}`, encode(soon), encode(later), encode(block.Bytes)))
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	all, err := certificate.Scan(context.Background(), client.PullZone)
+	require.NoError(t, err)
+	assert.Len(t, all, 4)
+
+	expiring, err := certificate.ScanExpiring(context.Background(), client.PullZone, 30)
+	require.NoError(t, err)
+	require.Len(t, expiring, 3)
+	assert.Equal(t, "sooner.example.com", expiring[0].Hostname)
+	assert.Equal(t, int64(2), expiring[0].PullZoneId)
+	assert.Equal(t, "soon.example.com", expiring[1].Hostname)
+	assert.Equal(t, "site", expiring[1].PullZoneName)
+	assert.Equal(t, "broken.example.com", expiring[2].Hostname)
+	assert.Error(t, expiring[2].Err)
+}
@@ -0,0 +1,14 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/venom90/bunnynet-go/common"
+)
+
+func TestNormalizeDomain(t *testing.T) {
+	assert.Equal(t, "example.com", common.NormalizeDomain(" Example.COM. "))
+	assert.Equal(t, "api.example.com", common.NormalizeDomain("api.example.com"))
+	assert.Equal(t, "", common.NormalizeDomain("."))
+}
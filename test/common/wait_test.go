@@ -0,0 +1,72 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go/common"
+)
+
+func TestPoll_Done(t *testing.T) {
+	var progress []common.WaitProgress
+	attempts := 0
+
+	err := common.Poll(context.Background(), &common.WaitOptions{
+		Interval:    time.Millisecond,
+		Multiplier:  2,
+		MaxInterval: 3 * time.Millisecond,
+		Progress:    func(p common.WaitProgress) { progress = append(progress, p) },
+	}, func(ctx context.Context) (bool, error) {
+		attempts++
+		return attempts == 4, nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, attempts)
+	require.Len(t, progress, 3)
+	assert.Equal(t, 1, progress[0].Attempt)
+	assert.Equal(t, time.Millisecond, progress[0].Next)
+	assert.Equal(t, 2*time.Millisecond, progress[1].Next)
+	assert.Equal(t, 3*time.Millisecond, progress[2].Next)
+}
+
+func TestPoll_ConditionError(t *testing.T) {
+	failure := errors.New("boom")
+	attempts := 0
+
+	err := common.Poll(context.Background(), &common.WaitOptions{Interval: time.Millisecond}, func(ctx context.Context) (bool, error) {
+		attempts++
+		return false, failure
+	})
+
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestPoll_Timeout(t *testing.T) {
+	err := common.Poll(context.Background(), &common.WaitOptions{
+		Interval: time.Millisecond,
+		Timeout:  20 * time.Millisecond,
+	}, func(ctx context.Context) (bool, error) {
+		return false, nil
+	})
+
+	assert.ErrorIs(t, err, common.ErrWaitTimeout)
+	assert.NotErrorIs(t, err, context.Canceled)
+}
+
+func TestPoll_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := common.Poll(ctx, &common.WaitOptions{Interval: time.Hour}, func(ctx context.Context) (bool, error) {
+		cancel()
+		return false, nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, common.ErrWaitTimeout)
+}
@@ -0,0 +1,213 @@
+package ddns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/ddns"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+func staticDetector(addr string) ddns.Detector {
+	return ddns.DetectorFunc(func(ctx context.Context) (netip.Addr, error) {
+		return netip.MustParseAddr(addr), nil
+	})
+}
+
+func TestHTTPDetector_EchoHandler(t *testing.T) {
+	server := httptest.NewServer(ddns.EchoHandler())
+	defer server.Close()
+
+	detector := &ddns.HTTPDetector{URL: server.URL, Family: ddns.IPv4}
+	addr, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("127.0.0.1"), addr)
+
+	// The echo endpoint reports an IPv4 address, which is rejected for IPv6
+	detector = &ddns.HTTPDetector{URL: server.URL, Family: ddns.IPv6, Client: server.Client()}
+	_, err = detector.Detect(context.Background())
+	assert.Error(t, err)
+}
+
+func TestHTTPDetector_InvalidResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "<html>not an address</html>")
+	}))
+	defer server.Close()
+
+	detector := &ddns.HTTPDetector{URL: server.URL, Family: ddns.IPv4}
+	_, err := detector.Detect(context.Background())
+	assert.Error(t, err)
+}
+
+func TestParseDetector(t *testing.T) {
+	detector, err := ddns.ParseDetector("http:https://api.ipify.org", ddns.IPv4)
+	require.NoError(t, err)
+	assert.Equal(t, &ddns.HTTPDetector{URL: "https://api.ipify.org", Family: ddns.IPv4}, detector)
+
+	detector, err = ddns.ParseDetector("https://api6.ipify.org", ddns.IPv6)
+	require.NoError(t, err)
+	assert.Equal(t, &ddns.HTTPDetector{URL: "https://api6.ipify.org", Family: ddns.IPv6}, detector)
+
+	detector, err = ddns.ParseDetector("iface:eth0", ddns.IPv6)
+	require.NoError(t, err)
+	assert.Equal(t, &ddns.InterfaceDetector{Interface: "eth0", Family: ddns.IPv6}, detector)
+
+	_, err = ddns.ParseDetector("dns:resolver", ddns.IPv4)
+	assert.Error(t, err)
+}
+
+func TestIsPublic(t *testing.T) {
+	assert.True(t, ddns.IsPublic(netip.MustParseAddr("203.0.113.10")))
+	assert.True(t, ddns.IsPublic(netip.MustParseAddr("2001:db8::1")))
+	assert.False(t, ddns.IsPublic(netip.MustParseAddr("192.168.1.10")))
+	assert.False(t, ddns.IsPublic(netip.MustParseAddr("100.64.1.1")))
+	assert.False(t, ddns.IsPublic(netip.MustParseAddr("fd00::1")))
+	assert.False(t, ddns.IsPublic(netip.MustParseAddr("fe80::1")))
+}
+
+func TestInterfaceDetector_UnknownInterface(t *testing.T) {
+	detector := &ddns.InterfaceDetector{Interface: "does-not-exist0", Family: ddns.IPv4}
+	_, err := detector.Detect(context.Background())
+	assert.Error(t, err)
+}
+
+func TestUpdater_Update(t *testing.T) {
+	gets := 0
+	var updates []resources.UpdateDNSRecordOptions
+	var adds []resources.AddDNSRecordOptions
+
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /dnszone/10": func(w http.ResponseWriter, r *http.Request) {
+			gets++
+			test.RespondJSON(w, http.StatusOK, `{
+				"Id": 10,
+				"Domain": "example.com",
+				"Records": [
+					{"Id": 1, "Type": 0, "Name": "home", "Value": "198.51.100.1", "Ttl": 300},
+					{"Id": 2, "Type": 0, "Name": "", "Value": "203.0.113.10", "Ttl": 60},
+					{"Id": 3, "Type": 1, "Name": "home", "Value": "2001:db8::1", "Ttl": 60},
+					{"Id": 4, "Type": 2, "Name": "www", "Value": "home.example.com"}
+				]
+			}`)
+		},
+		"POST /dnszone/10/records/{id}": func(w http.ResponseWriter, r *http.Request) {
+			var options resources.UpdateDNSRecordOptions
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+			updates = append(updates, options)
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"PUT /dnszone/10/records": func(w http.ResponseWriter, r *http.Request) {
+			var options resources.AddDNSRecordOptions
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+			adds = append(adds, options)
+			test.RespondJSON(w, http.StatusCreated, `{"Id": 5, "Type": 1, "Name": "", "Value": "2001:db8::1"}`)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+	stateFile := filepath.Join(t.TempDir(), "ddns.json")
+
+	config := ddns.Config{
+		ZoneId:        10,
+		Names:         []string{"home", "@"},
+		IPv4:          staticDetector("203.0.113.10"),
+		IPv6:          staticDetector("2001:db8::1"),
+		Ttl:           60,
+		CreateMissing: true,
+		StateFile:     stateFile,
+	}
+
+	result, err := ddns.NewUpdater(client.DNSZone, config).Update(context.Background())
+	require.NoError(t, err)
+	assert.False(t, result.Cached)
+	assert.Equal(t, 1, gets)
+	assert.Equal(t, 2, result.Unchanged)
+
+	require.Len(t, updates, 1)
+	assert.Equal(t, int64(1), updates[0].Id)
+	assert.Equal(t, "203.0.113.10", *updates[0].Value)
+	assert.Equal(t, int32(60), *updates[0].Ttl)
+
+	require.Len(t, adds, 1)
+	assert.Equal(t, resources.DNSRecordTypeAAAA, adds[0].Type)
+	assert.Equal(t, "@", adds[0].Name)
+
+	require.Len(t, result.Changes, 2)
+	assert.Equal(t, netip.MustParseAddr("198.51.100.1"), result.Changes[0].From)
+	assert.False(t, result.Changes[1].From.IsValid())
+
+	state, err := ddns.LoadState(stateFile)
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("203.0.113.10"), state.IPv4)
+	assert.Equal(t, netip.MustParseAddr("2001:db8::1"), state.IPv6)
+
+	// A new updater with the same state file does not call the API while the addresses are unchanged
+	result, err = ddns.NewUpdater(client.DNSZone, config).Update(context.Background())
+	require.NoError(t, err)
+	assert.True(t, result.Cached)
+	assert.Equal(t, 1, gets)
+
+	// A changed configuration invalidates the state
+	config.Names = []string{"home"}
+	config.IPv6 = nil
+	_, err = ddns.NewUpdater(client.DNSZone, config).Update(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, gets)
+}
+
+func TestUpdater_Update_Errors(t *testing.T) {
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /dnszone/10": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{
+				"Id": 10,
+				"Records": [
+					{"Id": 1, "Type": 0, "Name": "home", "Value": "198.51.100.1"},
+					{"Id": 2, "Type": 0, "Name": "home", "Value": "198.51.100.2"}
+				]
+			}`)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+	detectErr := errors.New("no route to host")
+
+	updater := ddns.NewUpdater(client.DNSZone, ddns.Config{
+		ZoneId: 10,
+		Names:  []string{"home", "office"},
+		IPv4:   staticDetector("203.0.113.10"),
+		IPv6: ddns.DetectorFunc(func(ctx context.Context) (netip.Addr, error) {
+			return netip.Addr{}, detectErr
+		}),
+	})
+
+	result, err := updater.Update(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, detectErr)
+	assert.ErrorContains(t, err, "A home: found 2 records")
+	assert.ErrorContains(t, err, "A office: record not found")
+	assert.Empty(t, result.Changes)
+	assert.Equal(t, netip.MustParseAddr("203.0.113.10"), result.IPv4)
+}
+
+func TestUpdater_Run_InvalidInterval(t *testing.T) {
+	updater := ddns.NewUpdater(nil, ddns.Config{ZoneId: 10, Names: []string{"home"}, IPv4: staticDetector("203.0.113.10")})
+
+	err := updater.Run(context.Background(), 0, func(*ddns.Result, error) {
+		t.Error("no update should run for a non-positive interval")
+	})
+	assert.ErrorContains(t, err, "interval must be positive")
+}
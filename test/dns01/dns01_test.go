@@ -0,0 +1,189 @@
+package dns01
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/dns01"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+// fakeZone is an in-memory DNS zone served by the mock API
+type fakeZone struct {
+	mu      sync.Mutex
+	nextId  int64
+	records map[int64]resources.DNSRecord
+	deleted []int64
+}
+
+func (z *fakeZone) visible(fqdn, value string) bool {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	for _, record := range z.records {
+		if record.Name+".example.com." == fqdn && record.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func setupProvider(t *testing.T, checker dns01.Checker) (*bunnynet.Client, *dns01.Provider, *fakeZone, func()) {
+	zone := &fakeZone{nextId: 100, records: map[int64]resources.DNSRecord{}}
+
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /dnszone": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{
+				"Items": [{"Id": 10, "Domain": "example.com"}, {"Id": 11, "Domain": "example.org"}],
+				"CurrentPage": 1,
+				"TotalItems": 2,
+				"HasMoreItems": false
+			}`)
+		},
+		"GET /dnszone/10": func(w http.ResponseWriter, r *http.Request) {
+			zone.mu.Lock()
+			defer zone.mu.Unlock()
+			records := make([]resources.DNSRecord, 0, len(zone.records))
+			for _, record := range zone.records {
+				records = append(records, record)
+			}
+			json.NewEncoder(w).Encode(resources.DNSZone{Id: 10, Domain: "example.com", Records: records})
+		},
+		"PUT /dnszone/10/records": func(w http.ResponseWriter, r *http.Request) {
+			var options resources.AddDNSRecordOptions
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+			assert.Equal(t, resources.DNSRecordTypeTXT, options.Type)
+			assert.Equal(t, int32(60), options.Ttl)
+
+			zone.mu.Lock()
+			zone.nextId++
+			record := resources.DNSRecord{Id: zone.nextId, Type: options.Type, Name: options.Name, Value: options.Value}
+			zone.records[record.Id] = record
+			zone.mu.Unlock()
+
+			test.RespondJSON(w, http.StatusCreated, fmt.Sprintf(`{"Id": %d, "Type": 3, "Name": %q, "Value": %q}`, record.Id, record.Name, record.Value))
+		},
+		"DELETE /dnszone/10/records/{id}": func(w http.ResponseWriter, r *http.Request) {
+			var id int64
+			fmt.Sscan(r.PathValue("id"), &id)
+
+			zone.mu.Lock()
+			delete(zone.records, id)
+			zone.deleted = append(zone.deleted, id)
+			zone.mu.Unlock()
+
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+
+	if checker == nil {
+		checker = dns01.CheckerFunc(func(ctx context.Context, fqdn, value string) (bool, error) {
+			return zone.visible(fqdn, value), nil
+		})
+	}
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+	provider := dns01.NewProvider(client.DNSZone, dns01.Config{
+		PollingInterval:    time.Millisecond,
+		PropagationTimeout: 200 * time.Millisecond,
+		Checker:            checker,
+	})
+
+	return client, provider, zone, server.Close
+}
+
+func TestChallengeRecord(t *testing.T) {
+	fqdn, value := dns01.ChallengeRecord("www.example.com", "abc")
+	assert.Equal(t, "_acme-challenge.www.example.com.", fqdn)
+	assert.Equal(t, "ungWv48Bz-pBQUDeXa4iI7ADYaOWF3qctBD_YfIAFa0", value)
+
+	fqdn, _ = dns01.ChallengeRecord("*.example.com", "abc")
+	assert.Equal(t, "_acme-challenge.example.com.", fqdn)
+}
+
+func TestProvider_PresentCleanUp_Concurrent(t *testing.T) {
+	_, provider, zone, cleanup := setupProvider(t, nil)
+	defer cleanup()
+
+	// A SAN certificate for the apex, a wildcard and a subdomain presents three challenges at once
+	domains := []string{"example.com", "*.example.com", "www.example.com"}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(domains))
+	for i, domain := range domains {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = provider.Present(domain, "token", "key-"+domain)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Len(t, zone.records, 3)
+
+	names := map[string]int{}
+	for _, record := range zone.records {
+		names[record.Name]++
+	}
+	assert.Equal(t, map[string]int{"_acme-challenge": 2, "_acme-challenge.www": 1}, names)
+
+	for _, domain := range domains {
+		require.NoError(t, provider.CleanUp(domain, "token", "key-"+domain))
+	}
+	assert.Empty(t, zone.records)
+	assert.Len(t, zone.deleted, 3)
+}
+
+func TestProvider_CleanUp_UntrackedRecord(t *testing.T) {
+	client, provider, zone, cleanup := setupProvider(t, nil)
+	defer cleanup()
+
+	require.NoError(t, provider.Present("www.example.com", "token", "key"))
+	require.NoError(t, provider.Present("www.example.com", "token", "other-key"))
+	require.Len(t, zone.records, 2)
+
+	// A new provider, such as after a restart, finds the record by name and value
+	restarted := dns01.NewProvider(client.DNSZone, dns01.Config{ZoneId: 10})
+	require.NoError(t, restarted.CleanUp("www.example.com", "token", "key"))
+	require.Len(t, zone.records, 1)
+	_, value := dns01.ChallengeRecord("www.example.com", "other-key")
+	for _, record := range zone.records {
+		assert.Equal(t, value, record.Value)
+	}
+
+	// Cleaning up a record that no longer exists succeeds
+	require.NoError(t, restarted.CleanUp("www.example.com", "token", "key"))
+}
+
+func TestProvider_Present_Errors(t *testing.T) {
+	_, provider, zone, cleanup := setupProvider(t, dns01.CheckerFunc(func(ctx context.Context, fqdn, value string) (bool, error) {
+		return false, nil
+	}))
+	defer cleanup()
+
+	err := provider.Present("www.example.net", "token", "key")
+	assert.ErrorIs(t, err, common.ErrNotFound)
+
+	err = provider.Present("www.example.com", "token", "key")
+	assert.ErrorIs(t, err, common.ErrWaitTimeout)
+
+	// The record is still tracked, so it can be cleaned up after the failed challenge
+	require.NoError(t, provider.CleanUp("www.example.com", "token", "key"))
+	assert.Empty(t, zone.records)
+
+	timeout, interval := provider.Timeout()
+	assert.Equal(t, 200*time.Millisecond, timeout)
+	assert.Equal(t, time.Millisecond, interval)
+}
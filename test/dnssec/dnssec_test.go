@@ -0,0 +1,242 @@
+package dnssec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go/dnssec"
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// testPublicKey is the DNSKEY of dskey.example.com used in the examples of RFC 4034 and RFC 4509
+const testPublicKey = "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw=="
+
+const (
+	testSHA1Digest   = "2BB183AF5F22588179A53B0A98631FAD1A292118"
+	testSHA256Digest = "D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A"
+)
+
+func testInfo() *resources.DNSSecInfo {
+	return &resources.DNSSecInfo{
+		Enabled:    true,
+		DsRecord:   "dskey.example.com. 86400 IN DS 60485 5 1 " + testSHA1Digest,
+		Digest:     testSHA1Digest,
+		DigestType: "SHA-1",
+		Algorithm:  5,
+		PublicKey:  testPublicKey,
+		KeyTag:     60485,
+		Flags:      256,
+	}
+}
+
+func TestDNSKEY_ComputeDS(t *testing.T) {
+	info, err := dnssec.Parse("", testInfo())
+	require.NoError(t, err)
+
+	assert.Equal(t, uint16(60485), info.Key.KeyTag())
+
+	sha1, err := info.Key.ComputeDS(dnssec.DigestSHA1)
+	require.NoError(t, err)
+	assert.Equal(t, testSHA1Digest, sha1.DigestHex())
+
+	sha256, err := info.Key.ComputeDS(dnssec.DigestSHA256)
+	require.NoError(t, err)
+	assert.Equal(t, testSHA256Digest, sha256.DigestHex())
+	assert.Equal(t, "60485 5 2 "+testSHA256Digest, sha256.RDATA())
+
+	_, err = info.Key.ComputeDS(9)
+	assert.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	info, err := dnssec.Parse("", testInfo())
+	require.NoError(t, err)
+
+	assert.Equal(t, "dskey.example.com", info.DS.Owner)
+	assert.Equal(t, uint32(86400), info.DS.TTL)
+	assert.Equal(t, uint16(60485), info.DS.KeyTag)
+	assert.Equal(t, uint8(5), info.DS.Algorithm)
+	assert.Equal(t, uint8(dnssec.DigestSHA1), info.DS.DigestType)
+	assert.Equal(t, uint16(256), info.Key.Flags)
+	assert.Equal(t, uint8(3), info.Key.Protocol)
+	assert.NoError(t, info.Verify())
+
+	// The domain argument overrides the owner of the DS record
+	info, err = dnssec.Parse("Other.Example.", testInfo())
+	require.NoError(t, err)
+	assert.Equal(t, "other.example", info.DS.Owner)
+	assert.Equal(t, "other.example", info.Key.Owner)
+
+	_, err = dnssec.Parse("example.com", &resources.DNSSecInfo{})
+	assert.Error(t, err)
+
+	bad := testInfo()
+	bad.PublicKey = "not base64!"
+	_, err = dnssec.Parse("", bad)
+	assert.Error(t, err)
+}
+
+func TestInfo_Verify_Mismatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(info *resources.DNSSecInfo)
+		want   error
+	}{
+		{
+			name:   "digest",
+			modify: func(info *resources.DNSSecInfo) { info.Digest = strings.Repeat("00", 20) },
+			want:   dnssec.ErrDigestMismatch,
+		},
+		{
+			name:   "key tag",
+			modify: func(info *resources.DNSSecInfo) { info.KeyTag = 12345 },
+			want:   dnssec.ErrKeyTagMismatch,
+		},
+		{
+			name:   "owner",
+			modify: func(info *resources.DNSSecInfo) { info.DsRecord = "" },
+			want:   dnssec.ErrDigestMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := testInfo()
+			tt.modify(raw)
+
+			domain := ""
+			if raw.DsRecord == "" {
+				domain = "example.com"
+			}
+			info, err := dnssec.Parse(domain, raw)
+			require.NoError(t, err)
+
+			err = info.Verify()
+			assert.True(t, errors.Is(err, tt.want), "got %v", err)
+		})
+	}
+
+	info, err := dnssec.Parse("", testInfo())
+	require.NoError(t, err)
+	info.DS.Algorithm = 13
+	assert.ErrorIs(t, info.Verify(), dnssec.ErrAlgorithmMismatch)
+}
+
+func TestParseDS(t *testing.T) {
+	tests := []struct {
+		input string
+		owner string
+		ttl   uint32
+	}{
+		{input: "dskey.example.com. 86400 IN DS 60485 5 1 " + testSHA1Digest, owner: "dskey.example.com", ttl: 86400},
+		{input: "dskey.example.com. IN DS 60485 5 1 " + testSHA1Digest, owner: "dskey.example.com"},
+		{input: "60485 5 1 " + testSHA1Digest},
+		{input: "60485 5 SHA-1 ( 2BB183AF5F22588179A53B0A 98631FAD1A292118 )"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			ds, err := dnssec.ParseDS(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.owner, ds.Owner)
+			assert.Equal(t, tt.ttl, ds.TTL)
+			assert.Equal(t, uint16(60485), ds.KeyTag)
+			assert.Equal(t, uint8(5), ds.Algorithm)
+			assert.Equal(t, uint8(dnssec.DigestSHA1), ds.DigestType)
+			assert.Equal(t, testSHA1Digest, ds.DigestHex())
+		})
+	}
+
+	for _, input := range []string{"", "60485 5 1", "x 5 1 AB", "60485 5 1 XYZ", "60485 5 SHA-999 AB"} {
+		_, err := dnssec.ParseDS(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestInfo_Render(t *testing.T) {
+	info, err := dnssec.Parse("", testInfo())
+	require.NoError(t, err)
+
+	zone, err := info.Render(dnssec.FormatZone)
+	require.NoError(t, err)
+	assert.Equal(t, "dskey.example.com. 86400 IN DS 60485 5 1 "+testSHA1Digest, zone)
+
+	rdata, err := info.Render(dnssec.FormatRDATA)
+	require.NoError(t, err)
+	assert.Equal(t, "60485 5 1 "+testSHA1Digest, rdata)
+
+	fields, err := info.Render(dnssec.FormatFields)
+	require.NoError(t, err)
+	assert.Contains(t, fields, "Key Tag: 60485\n")
+	assert.Contains(t, fields, "Algorithm: 5 (RSASHA1)\n")
+	assert.Contains(t, fields, "Digest Type: 1 (SHA-1)\n")
+	assert.Contains(t, fields, "Digest: "+testSHA1Digest+"\n")
+
+	key, err := info.Render(dnssec.FormatDNSKEY)
+	require.NoError(t, err)
+	assert.Equal(t, "256 3 5 "+testPublicKey, key)
+
+	data, err := info.Render(dnssec.FormatJSON)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(data), &decoded))
+	assert.Equal(t, float64(60485), decoded["keyTag"])
+	assert.Equal(t, float64(5), decoded["algorithm"])
+	assert.Equal(t, float64(1), decoded["digestType"])
+	assert.Equal(t, testSHA1Digest, decoded["digest"])
+	assert.Equal(t, testPublicKey, decoded["publicKey"])
+
+	_, err = info.Render(dnssec.Format(99))
+	assert.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	info, err := dnssec.Parse("", testInfo())
+	require.NoError(t, err)
+
+	sha256, err := dnssec.ParseDS("60485 5 2 " + testSHA256Digest)
+	require.NoError(t, err)
+	old, err := dnssec.ParseDS("11111 5 2 " + testSHA256Digest)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		published []dnssec.DS
+		status    dnssec.Status
+		stale     int
+	}{
+		{name: "missing", status: dnssec.StatusMissing},
+		{name: "same digest type", published: []dnssec.DS{info.DS}, status: dnssec.StatusMatch},
+		{name: "other digest type", published: []dnssec.DS{sha256}, status: dnssec.StatusMatch},
+		{name: "rollover", published: []dnssec.DS{old, sha256}, status: dnssec.StatusMatch, stale: 1},
+		{name: "stale", published: []dnssec.DS{old}, status: dnssec.StatusMismatch, stale: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queried string
+			resolver := dnssec.ResolverFunc(func(ctx context.Context, domain string) ([]dnssec.DS, error) {
+				queried = domain
+				return tt.published, nil
+			})
+
+			result, err := dnssec.Check(context.Background(), resolver, info)
+			require.NoError(t, err)
+			assert.Equal(t, "dskey.example.com", queried)
+			assert.Equal(t, tt.status, result.Status)
+			assert.Len(t, result.Stale, tt.stale)
+			assert.Equal(t, info.DS, result.Expected)
+		})
+	}
+
+	failing := dnssec.ResolverFunc(func(ctx context.Context, domain string) ([]dnssec.DS, error) {
+		return nil, errors.New("servfail")
+	})
+	_, err = dnssec.Check(context.Background(), failing, info)
+	assert.ErrorContains(t, err, "servfail")
+}
@@ -0,0 +1,140 @@
+package drift
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/drift"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+func snapshotZone() *resources.PullZone {
+	return &resources.PullZone{
+		Id:                   5,
+		Name:                 "site",
+		OriginUrl:            "https://origin.example.com",
+		EnableWebPVary:       true,
+		BlockedIps:           []string{"192.0.2.1", "192.0.2.2"},
+		MonthlyBandwidthUsed: 100,
+		Hostnames: []resources.Hostname{
+			{Id: 1, Value: "site.b-cdn.net", IsSystemHostname: true},
+			{Id: 2, Value: "cdn.example.com", ForceSSL: true},
+		},
+		EdgeRules: []resources.EdgeRule{
+			{Guid: "rule-1", ActionType: resources.EdgeRuleActionTypeSetResponseHeader, ActionParameter1: "X-Frame-Options", ActionParameter2: "DENY", Enabled: true},
+			{Guid: "rule-2", ActionType: resources.EdgeRuleActionTypeBlockRequest, Description: "Block admin", Enabled: true},
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	snapshot := snapshotZone()
+	assert.Empty(t, drift.Compare(snapshot, snapshotZone(), nil))
+
+	live := snapshotZone()
+	live.EnableWebPVary = false
+	live.MonthlyBandwidthUsed = 5000
+	live.BlockedIps = []string{"192.0.2.2", "198.51.100.0/24"}
+	live.AllowedReferrers = []string{}
+	live.Hostnames = []resources.Hostname{
+		{Id: 1, Value: "site.b-cdn.net", IsSystemHostname: true},
+		{Id: 2, Value: "CDN.example.com"},
+		{Id: 3, Value: "www.example.com"},
+	}
+	live.EdgeRules = []resources.EdgeRule{
+		{Guid: "rule-1", ActionType: resources.EdgeRuleActionTypeSetResponseHeader, ActionParameter1: "X-Frame-Options", ActionParameter2: "SAMEORIGIN", Enabled: true},
+		{Guid: "rule-3", ActionType: resources.EdgeRuleActionTypeForceSSL, Enabled: true},
+	}
+
+	changes := drift.Compare(snapshot, live, nil)
+	assert.Equal(t, []drift.Change{
+		{Path: "BlockedIps", Kind: drift.ChangeKindRemoved, Old: "192.0.2.1"},
+		{Path: "BlockedIps", Kind: drift.ChangeKindAdded, New: "198.51.100.0/24"},
+		{Path: "EdgeRules", Kind: drift.ChangeKindRemoved, Old: "rule-2 (Block admin)"},
+		{Path: "EdgeRules", Kind: drift.ChangeKindAdded, New: "rule-3"},
+		{Path: "EdgeRules[rule-1].ActionParameter2", Kind: drift.ChangeKindModified, Old: "DENY", New: "SAMEORIGIN"},
+		{Path: "EnableWebPVary", Kind: drift.ChangeKindModified, Old: true, New: false},
+		{Path: "Hostnames", Kind: drift.ChangeKindAdded, New: "www.example.com"},
+		{Path: "Hostnames[cdn.example.com].ForceSSL", Kind: drift.ChangeKindModified, Old: true, New: false},
+	}, changes)
+
+	withUsage := drift.Compare(snapshot, live, &drift.Options{Ignore: []string{}})
+	assert.Contains(t, withUsage, drift.Change{Path: "MonthlyBandwidthUsed", Kind: drift.ChangeKindModified, Old: int64(100), New: int64(5000)})
+}
+
+func TestCompare_EdgeRulesWithoutGuid(t *testing.T) {
+	snapshot := &resources.PullZone{
+		EdgeRules: []resources.EdgeRule{
+			{ActionType: resources.EdgeRuleActionTypeForceSSL, Enabled: true},
+			{ActionType: resources.EdgeRuleActionTypeBlockRequest, Description: "Block admin", Enabled: true},
+			{ActionType: resources.EdgeRuleActionTypeSetStatusCode, ActionParameter1: "410", Enabled: true},
+		},
+	}
+	live := &resources.PullZone{
+		EdgeRules: []resources.EdgeRule{
+			{Guid: "rule-1", ActionType: resources.EdgeRuleActionTypeForceSSL, Enabled: true},
+			{Guid: "rule-2", ActionType: resources.EdgeRuleActionTypeBlockRequest, Description: "Block admin", Enabled: false},
+			{Guid: "rule-3", ActionType: resources.EdgeRuleActionTypeSetStatusCode, ActionParameter1: "404", Enabled: true},
+		},
+	}
+
+	// Rules without a Guid are matched by description and order instead of overwriting each other
+	assert.Equal(t, []drift.Change{
+		{Path: "EdgeRules[rule-2].Enabled", Kind: drift.ChangeKindModified, Old: true, New: false},
+		{Path: "EdgeRules[rule-3].ActionParameter1", Kind: drift.ChangeKindModified, Old: "410", New: "404"},
+	}, drift.Compare(snapshot, live, nil))
+	assert.Empty(t, drift.Compare(snapshot, snapshot, nil))
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	zone := snapshotZone()
+
+	for _, name := range []string{"zone.json", "zone.yaml"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, drift.SaveSnapshot(path, zone))
+
+		loaded, err := drift.LoadSnapshot(path)
+		require.NoError(t, err, name)
+		assert.Empty(t, drift.Compare(zone, loaded, &drift.Options{Ignore: []string{}}), name)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "zone.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "OriginUrl: https://origin.example.com")
+
+	_, err = drift.ParseSnapshot([]byte(`{"Orign": "typo"}`), false)
+	assert.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /pullzone/5": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{"Id": 5, "Name": "site", "OriginUrl": "https://new-origin.example.com"}`)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	snapshot := &resources.PullZone{Id: 5, Name: "site", OriginUrl: "https://origin.example.com"}
+	report, err := drift.Check(context.Background(), client.PullZone, snapshot, nil)
+	require.NoError(t, err)
+	assert.True(t, report.HasDrift())
+	assert.Equal(t, `Pull Zone site (5) has drifted from the snapshot:
+
+  ~ OriginUrl: "https://origin.example.com" => "https://new-origin.example.com"
+
+Drift: 0 added, 1 modified, 0 removed.
+`, report.String())
+
+	clean := &drift.Report{PullZoneId: 5, Name: "site"}
+	assert.Equal(t, "Pull Zone site (5) matches the snapshot.\n", clean.String())
+}
@@ -0,0 +1,161 @@
+package edgerule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/edgerule"
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+func TestRule_Build_Redirect(t *testing.T) {
+	options, err := edgerule.Redirect("https://example.com/new", 301).
+		When(edgerule.URLMatches("*/old/*"), edgerule.Country("DE")).
+		MatchAll().
+		Describe("Redirect old pages").
+		Build()
+	require.NoError(t, err, "Build should not return an error")
+
+	assert.Equal(t, resources.EdgeRuleActionTypeRedirect, options.ActionType)
+	assert.Equal(t, "https://example.com/new", options.ActionParameter1)
+	assert.Equal(t, "301", options.ActionParameter2)
+	assert.Equal(t, resources.TriggerMatchingTypeMatchAll, options.TriggerMatchingType)
+	assert.Equal(t, "Redirect old pages", options.Description)
+	assert.True(t, options.Enabled)
+
+	require.Len(t, options.Triggers, 2)
+	assert.Equal(t, resources.EdgeRuleTriggerTypeUrl, options.Triggers[0].Type)
+	assert.Equal(t, []string{"*/old/*"}, options.Triggers[0].PatternMatches)
+	assert.Equal(t, resources.EdgeRuleTriggerTypeCountryCode, options.Triggers[1].Type)
+}
+
+func TestRule_Build_RedirectWithVariables(t *testing.T) {
+	options, err := edgerule.Redirect("https://new.example.com%{Url.Path}", 301).
+		When(edgerule.URLMatches("*")).
+		Build()
+	require.NoError(t, err, "dynamic variables in the redirect URL should be accepted")
+	assert.Equal(t, "https://new.example.com%{Url.Path}", options.ActionParameter1)
+
+	_, err = edgerule.OriginURL("https://%{Request.Hostname}/origin").When(edgerule.URLMatches("*")).Build()
+	assert.NoError(t, err)
+
+	_, err = edgerule.Redirect("%{Url.Path}", 301).When(edgerule.URLMatches("*")).Build()
+	assert.Error(t, err, "a URL made only of a variable is still not absolute")
+}
+
+func TestRule_Build_Actions(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       *edgerule.Rule
+		action     resources.EdgeRuleActionType
+		parameter1 string
+		parameter2 string
+	}{
+		{"force ssl", edgerule.ForceSSL(), resources.EdgeRuleActionTypeForceSSL, "", ""},
+		{"origin url", edgerule.OriginURL("https://origin.example.com"), resources.EdgeRuleActionTypeOriginUrl, "https://origin.example.com", ""},
+		{"block", edgerule.Block(), resources.EdgeRuleActionTypeBlockRequest, "", ""},
+		{"response header", edgerule.SetResponseHeader("X-Frame-Options", "DENY"), resources.EdgeRuleActionTypeSetResponseHeader, "X-Frame-Options", "DENY"},
+		{"request header", edgerule.SetRequestHeader("X-Origin", "cdn"), resources.EdgeRuleActionTypeSetRequestHeader, "X-Origin", "cdn"},
+		{"cache time", edgerule.OverrideCacheTime(time.Hour), resources.EdgeRuleActionTypeOverrideCacheTime, "3600", ""},
+		{"browser cache time", edgerule.OverrideBrowserCacheTime(time.Minute), resources.EdgeRuleActionTypeOverrideBrowserCacheTime, "60", ""},
+		{"status code", edgerule.SetStatusCode(404), resources.EdgeRuleActionTypeSetStatusCode, "404", ""},
+		{"rate limit", edgerule.SetRequestsPerSecondLimit(10), resources.EdgeRuleActionTypeSetRequestsPerSecondLimit, "10", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := tt.rule.When(edgerule.URLMatches("*")).Build()
+			require.NoError(t, err)
+			assert.Equal(t, tt.action, options.ActionType)
+			assert.Equal(t, tt.parameter1, options.ActionParameter1)
+			assert.Equal(t, tt.parameter2, options.ActionParameter2)
+		})
+	}
+}
+
+func TestTriggers(t *testing.T) {
+	header := edgerule.RequestHeader("User-Agent", "*bot*")
+	assert.Equal(t, resources.EdgeRuleTriggerTypeRequestHeader, header.Type)
+	assert.Equal(t, "User-Agent", header.Parameter1)
+	assert.Equal(t, []string{"*bot*"}, header.PatternMatches)
+
+	extension := edgerule.Extension(".jpg", "png")
+	assert.Equal(t, []string{"jpg", "png"}, extension.PatternMatches)
+
+	status := edgerule.StatusCode(404, 500)
+	assert.Equal(t, resources.EdgeRuleTriggerTypeStatusCode, status.Type)
+	assert.Equal(t, []string{"404", "500"}, status.PatternMatches)
+
+	chance := edgerule.RandomChance(25)
+	assert.Equal(t, "25", chance.Parameter1)
+
+	negated := edgerule.Not(edgerule.RemoteIP("203.0.113.0/24"))
+	assert.Equal(t, resources.PatternMatchingTypeMatchNone, negated.PatternMatchingType)
+
+	all := edgerule.All(edgerule.Cookie("session", "*a*", "*b*"))
+	assert.Equal(t, resources.PatternMatchingTypeMatchAll, all.PatternMatchingType)
+	assert.Equal(t, resources.EdgeRuleTriggerTypeCookieValue, all.Type)
+}
+
+func TestRule_Disabled(t *testing.T) {
+	options := edgerule.ForceSSL().When(edgerule.URLMatches("*")).WithGuid("rule-1").Disabled().Options()
+	assert.False(t, options.Enabled)
+	assert.Equal(t, "rule-1", options.Guid)
+}
+
+func TestRule_Build_ValidationError(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  *edgerule.Rule
+		field string
+	}{
+		{"no triggers", edgerule.ForceSSL(), "Triggers"},
+		{"relative redirect", edgerule.Redirect("/new", 0).When(edgerule.URLMatches("*")), "ActionParameter1"},
+		{"bad redirect status", edgerule.Redirect("https://example.com", 200).When(edgerule.URLMatches("*")), "ActionParameter2"},
+		{"missing header name", edgerule.SetResponseHeader("", "x").When(edgerule.URLMatches("*")), "ActionParameter1"},
+		{"bad status code", edgerule.SetStatusCode(42).When(edgerule.URLMatches("*")), "ActionParameter1"},
+		{"missing cookie name", edgerule.Block().When(edgerule.Cookie("", "x")), "Triggers[0].Parameter1"},
+		{"bad country", edgerule.Block().When(edgerule.Country("Germany")), "Triggers[0].PatternMatches"},
+		{"bad ip", edgerule.Block().When(edgerule.RemoteIP("not-an-ip")), "Triggers[0].PatternMatches"},
+		{"no patterns", edgerule.Block().When(edgerule.URLMatches()), "Triggers[0].PatternMatches"},
+		{"bad chance", edgerule.Block().When(edgerule.RandomChance(150)), "Triggers[0].Parameter1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.rule.Build()
+			require.Error(t, err)
+
+			var validationErr *common.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Len(t, validationErr.Errors, 1)
+			assert.Equal(t, tt.field, validationErr.Errors[0].Field)
+		})
+	}
+}
+
+func TestValidate_UnknownTypes(t *testing.T) {
+	// Types added to the API later must still round-trip through the client
+	options := resources.AddOrUpdateEdgeRuleOptions{
+		ActionType: resources.EdgeRuleActionType(42),
+		Triggers:   []resources.EdgeRuleTrigger{{Type: resources.EdgeRuleTriggerType(42), PatternMatches: []string{"x"}}},
+	}
+	assert.NoError(t, options.Validate())
+
+	options.ActionType = -1
+	assert.ErrorContains(t, options.Validate(), "unknown action type -1")
+}
+
+func TestRule_Build_ValidPatterns(t *testing.T) {
+	_, err := edgerule.Block().
+		When(
+			edgerule.RemoteIP("192.0.2.1", "2001:db8::/32", "10.0.*"),
+			edgerule.OriginConnectionError(),
+			edgerule.RandomChance(50),
+		).
+		Build()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,150 @@
+package edgerule
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go/edgerule"
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"*", "", true},
+		{"*", "anything", true},
+		{"*/images/*", "https://cdn.example.com/images/logo.png", true},
+		{"*/images/*", "https://cdn.example.com/img/logo.png", false},
+		{"https://cdn.example.com/*.PNG", "https://cdn.example.com/a/b.png", true},
+		{"*.png", "https://cdn.example.com/a.png.html", false},
+		{"a*b*c", "aXXbYYc", true},
+		{"a*b*c", "aXXcYYb", false},
+		{"exact", "exact", true},
+		{"exact", "exactly", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, edgerule.MatchPattern(tt.pattern, tt.value), "%q against %q", tt.pattern, tt.value)
+	}
+}
+
+func TestEvaluate_Actions(t *testing.T) {
+	rules := []resources.EdgeRule{
+		edgerule.ForceSSL().When(edgerule.URLMatches("*")).EdgeRule(),
+		edgerule.Redirect("https://example.com/blog", 301).
+			When(edgerule.URLMatches("*/old-blog/*"), edgerule.Country("DE", "AT")).
+			MatchAll().
+			EdgeRule(),
+		edgerule.SetResponseHeader("X-Frame-Options", "DENY").When(edgerule.Extension("html")).EdgeRule(),
+		edgerule.Block().When(edgerule.RemoteIP("198.51.100.0/24")).EdgeRule(),
+		edgerule.Block().When(edgerule.URLMatches("*")).Disabled().EdgeRule(),
+	}
+
+	result := edgerule.Evaluate(rules, edgerule.Request{
+		URL:      "http://cdn.example.com/old-blog/post.html",
+		Country:  "DE",
+		RemoteIP: "192.0.2.10",
+	})
+
+	require.Len(t, result.Matches, 3)
+	assert.Equal(t, []int{0, 1, 2}, []int{result.Matches[0].Index, result.Matches[1].Index, result.Matches[2].Index})
+	assert.True(t, result.ForceSSL)
+	assert.Equal(t, "https://example.com/blog", result.RedirectURL)
+	assert.Equal(t, "301", result.RedirectStatusCode)
+	assert.Equal(t, "DENY", result.ResponseHeaders.Get("X-Frame-Options"))
+	assert.False(t, result.Blocked, "Disabled rules and non-matching IPs should not block")
+	assert.True(t, result.Matched(resources.EdgeRuleActionTypeRedirect))
+	assert.False(t, result.Matched(resources.EdgeRuleActionTypeBlockRequest))
+
+	// The redirect requires all triggers, so a different country does not match
+	result = edgerule.Evaluate(rules, edgerule.Request{
+		URL:      "https://cdn.example.com/old-blog/post.html",
+		Country:  "US",
+		RemoteIP: "198.51.100.7",
+	})
+	assert.Empty(t, result.RedirectURL)
+	assert.False(t, result.ForceSSL, "HTTPS requests should not be redirected")
+	assert.True(t, result.Blocked)
+}
+
+func TestEvaluate_TriggerMatchingNone(t *testing.T) {
+	rule := edgerule.Block().
+		When(edgerule.Country("US"), edgerule.Country("CA")).
+		MatchNone().
+		EdgeRule()
+
+	assert.True(t, edgerule.Matches(rule, edgerule.Request{URL: "https://cdn.example.com/", Country: "FR"}))
+	assert.False(t, edgerule.Matches(rule, edgerule.Request{URL: "https://cdn.example.com/", Country: "CA"}))
+}
+
+func TestTriggerMatches(t *testing.T) {
+	req := edgerule.Request{
+		URL:             "https://cdn.example.com/videos/clip.MP4?token=abc123",
+		Method:          http.MethodPost,
+		Headers:         http.Header{"User-Agent": []string{"Googlebot/2.1"}},
+		ResponseHeaders: http.Header{"Content-Type": []string{"video/mp4"}},
+		Cookies:         map[string]string{"session": "premium-user"},
+		Country:         "GB",
+		State:           "ENG",
+		RemoteIP:        "2001:db8::1",
+		StatusCode:      404,
+		Chance:          30,
+	}
+
+	tests := []struct {
+		name    string
+		trigger resources.EdgeRuleTrigger
+		want    bool
+	}{
+		{"url full", edgerule.URLMatches("https://cdn.example.com/videos/*"), true},
+		{"url query", edgerule.URLMatches("*?token=*"), true},
+		{"url path only", edgerule.URLMatches("/videos/*"), false},
+		{"url without scheme", edgerule.URLMatches("cdn.example.com/*"), false},
+		{"url no match", edgerule.URLMatches("*/images/*"), false},
+		{"request header", edgerule.RequestHeader("User-Agent", "*bot*"), true},
+		{"missing header", edgerule.RequestHeader("Referer", "*example*"), false},
+		{"response header", edgerule.ResponseHeader("Content-Type", "video/*"), true},
+		{"extension", edgerule.Extension("mp4", "webm"), true},
+		{"country", edgerule.Country("US", "GB"), true},
+		{"state", edgerule.CountryState("ENG"), true},
+		{"ipv6 cidr", edgerule.RemoteIP("2001:db8::/32"), true},
+		{"ip no match", edgerule.RemoteIP("192.0.2.1"), false},
+		{"query string", edgerule.QueryString("token", "abc*"), true},
+		{"cookie", edgerule.Cookie("session", "premium-*"), true},
+		{"status code", edgerule.StatusCode(404, 410), true},
+		{"method", edgerule.RequestMethod("GET"), false},
+		{"chance", edgerule.RandomChance(50), true},
+		{"chance miss", edgerule.RandomChance(10), false},
+		{"origin error", edgerule.OriginConnectionError(), false},
+		{"not", edgerule.Not(edgerule.Country("GB")), false},
+		{"all", edgerule.All(edgerule.URLMatches("*/videos/*", "*.mp4?*")), true},
+		{"all partial", edgerule.All(edgerule.URLMatches("*/videos/*", "*.webm?*")), false},
+		{"url ends before query", edgerule.URLMatches("*.mp4"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, edgerule.TriggerMatches(tt.trigger, req))
+		})
+	}
+}
+
+func TestEvaluatePullZone_LaterRulesOverride(t *testing.T) {
+	zone := &resources.PullZone{
+		EdgeRules: []resources.EdgeRule{
+			edgerule.OriginURL("https://a.example.com").When(edgerule.URLMatches("*")).EdgeRule(),
+			edgerule.OriginURL("https://b.example.com").When(edgerule.URLMatches("*/b/*")).EdgeRule(),
+		},
+	}
+
+	result := edgerule.EvaluatePullZone(zone, edgerule.Request{URL: "https://cdn.example.com/b/file"})
+	assert.Equal(t, "https://b.example.com", result.OriginURL)
+
+	result = edgerule.EvaluatePullZone(zone, edgerule.Request{URL: "https://cdn.example.com/a/file"})
+	assert.Equal(t, "https://a.example.com", result.OriginURL)
+}
@@ -0,0 +1,346 @@
+package iac
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/iac"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+const testConfig = `
+pullZones:
+  - name: site
+    originUrl: https://origin.example.com
+    hostnames: [cdn.example.com]
+    cache:
+      cacheControlMaxAgeOverride: 3600
+    edgeRules:
+      - description: Force SSL
+        actionType: 0
+        triggers:
+          - type: 0
+            patternMatches: ["*"]
+  - name: assets
+    originUrl: https://assets.example.com
+    type: 1
+dnsZones:
+  - domain: example.com
+    records:
+      - name: www
+        type: 0
+        value: 192.0.2.1
+        ttl: 600
+      - name: api
+        type: 2
+        value: api.example.net
+        ttl: 300
+      - name: static
+        type: 7
+        pullZone: assets
+`
+
+// liveState serves the live state used by the plan tests and records mutating requests
+type liveState struct {
+	mu       sync.Mutex
+	requests []string
+	bodies   map[string]string
+}
+
+func (s *liveState) record(r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := r.Method + " " + r.URL.Path
+	s.requests = append(s.requests, key)
+	s.bodies[key] = string(body)
+}
+
+func setupLiveServer(t *testing.T) (*bunnynet.Client, *liveState, func()) {
+	state := &liveState{bodies: make(map[string]string)}
+
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		state.record(r)
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /pullzone": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{
+				"Items": [
+					{
+						"Id": 1,
+						"Name": "site",
+						"OriginUrl": "https://origin.example.com",
+						"Hostnames": [
+							{"Id": 11, "Value": "site.b-cdn.net", "IsSystemHostname": true},
+							{"Id": 12, "Value": "old.example.com"}
+						],
+						"CacheControlMaxAgeOverride": 0,
+						"EdgeRules": [
+							{
+								"Guid": "rule-1",
+								"ActionType": 0,
+								"Triggers": [{"Type": 0, "PatternMatches": ["*"]}],
+								"Description": "Force SSL",
+								"Enabled": true
+							}
+						]
+					},
+					{"Id": 2, "Name": "legacy", "OriginUrl": "https://legacy.example.com", "Type": 1}
+				],
+				"CurrentPage": 1,
+				"TotalItems": 2,
+				"HasMoreItems": false
+			}`)
+		},
+		"POST /pullzone": func(w http.ResponseWriter, r *http.Request) {
+			state.record(r)
+			test.RespondJSON(w, http.StatusCreated, `{"Id": 3, "Name": "assets", "OriginUrl": "https://assets.example.com", "Type": 1}`)
+		},
+		"POST /pullzone/1": func(w http.ResponseWriter, r *http.Request) {
+			state.record(r)
+			test.RespondJSON(w, http.StatusOK, `{"Id": 1, "Name": "site"}`)
+		},
+		"POST /pullzone/1/addHostname":           ok,
+		"DELETE /pullzone/1/removeHostname":      ok,
+		"DELETE /pullzone/2":                     ok,
+		"POST /pullzone/1/edgerules/addOrUpdate": ok,
+		"GET /dnszone": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{
+				"Items": [{"Id": 10, "Domain": "example.com"}],
+				"CurrentPage": 1,
+				"TotalItems": 1,
+				"HasMoreItems": false
+			}`)
+		},
+		"GET /dnszone/10": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{
+				"Id": 10,
+				"Domain": "example.com",
+				"Records": [
+					{"Id": 100, "Type": 0, "Name": "www", "Value": "192.0.2.1", "Ttl": 300},
+					{"Id": 101, "Type": 3, "Name": "", "Value": "stale", "Ttl": 300}
+				]
+			}`)
+		},
+		"PUT /dnszone/10/records": func(w http.ResponseWriter, r *http.Request) {
+			state.record(r)
+			test.RespondJSON(w, http.StatusCreated, `{"Id": 200}`)
+		},
+		"POST /dnszone/10/records/100":   ok,
+		"DELETE /dnszone/10/records/101": ok,
+	})
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+	return client, state, server.Close
+}
+
+func TestParse_Success(t *testing.T) {
+	config, err := iac.Parse(strings.NewReader(testConfig))
+	require.NoError(t, err, "Parse should not return an error")
+
+	assert.Len(t, config.PullZones, 2)
+	assert.Equal(t, "site", config.PullZones[0].Name)
+	assert.Equal(t, []string{"cdn.example.com"}, config.PullZones[0].Hostnames)
+	assert.Nil(t, config.PullZones[1].Hostnames, "Omitted lists should not be managed")
+	assert.Equal(t, int64(3600), *config.PullZones[0].Cache.CacheControlMaxAgeOverride)
+	assert.Len(t, config.DNSZones[0].Records, 3)
+}
+
+func TestParse_RecordTypeNames(t *testing.T) {
+	config, err := iac.Parse(strings.NewReader("dnsZones:\n  - domain: example.com\n    records:\n      - {name: www, type: CNAME, value: example.net}\n      - {name: mail, type: mx, value: mx.example.net}\n"))
+	require.NoError(t, err, "Record types should be accepted by name")
+
+	assert.Equal(t, resources.DNSRecordTypeCNAME, config.DNSZones[0].Records[0].Type)
+	assert.Equal(t, resources.DNSRecordTypeMX, config.DNSZones[0].Records[1].Type)
+}
+
+func TestParse_PullZoneTypeNames(t *testing.T) {
+	config, err := iac.Parse(strings.NewReader("pullZones:\n  - name: a\n    originUrl: https://example.com\n    type: volume\n"))
+	require.NoError(t, err, "Pull zone types should be accepted by name")
+
+	require.NotNil(t, config.PullZones[0].Type)
+	assert.Equal(t, resources.PullZoneTypeVolume, *config.PullZones[0].Type)
+}
+
+func TestParse_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		error  string
+	}{
+		{"missing origin", "pullZones:\n  - name: site\n", "originUrl is required"},
+		{"duplicate pull zone", "pullZones:\n  - {name: a, originUrl: x}\n  - {name: A, originUrl: y}\n", "declared more than once"},
+		{"duplicate record", "dnsZones:\n  - domain: example.com\n    records:\n      - {name: www, type: 0, value: 1.1.1.1}\n      - {name: www, type: 0, value: 1.1.1.1}\n", "declared more than once"},
+		{"invalid edge rule", "pullZones:\n  - name: a\n    originUrl: x\n    edgeRules:\n      - {description: r, actionType: 1, triggers: [{type: 0, patternMatches: ['*']}]}\n", "ActionParameter1: a URL is required"},
+		{"unknown field", "pullZones:\n  - {name: a, originUrl: x, bogus: true}\n", "failed to parse configuration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := iac.Parse(strings.NewReader(tt.config))
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.error)
+		})
+	}
+}
+
+func TestBuildPlan_Success(t *testing.T) {
+	client, _, closeServer := setupLiveServer(t)
+	defer closeServer()
+
+	config, err := iac.Parse(strings.NewReader(testConfig))
+	require.NoError(t, err)
+
+	plan, err := iac.BuildPlan(context.Background(), client, config, nil)
+	require.NoError(t, err, "BuildPlan should not return an error")
+
+	var addresses []string
+	for _, change := range plan.Changes {
+		addresses = append(addresses, string(change.Action)+" "+change.Address)
+	}
+
+	assert.Equal(t, []string{
+		"update pullzone.site",
+		"create pullzone.assets",
+		"delete pullzone.site.hostname[old.example.com]",
+		"create pullzone.site.hostname[cdn.example.com]",
+		"delete dnszone.example.com.record[@ 3 stale]",
+		"update dnszone.example.com.record[www 0 192.0.2.1]",
+		"create dnszone.example.com.record[api 2 api.example.net]",
+		"create dnszone.example.com.record[static 7 assets]",
+	}, addresses)
+
+	add, change, destroy := plan.Summary()
+	assert.Equal(t, 4, add)
+	assert.Equal(t, 2, change)
+	assert.Equal(t, 2, destroy)
+
+	output := plan.String()
+	assert.Contains(t, output, "~ pullzone.site")
+	assert.Contains(t, output, "CacheControlMaxAgeOverride: 0 => 3600")
+	assert.Contains(t, output, "Ttl: 300 => 600")
+	assert.Contains(t, output, "Plan: 4 to add, 2 to change, 2 to destroy.")
+}
+
+func TestBuildPlan_Prune(t *testing.T) {
+	client, _, closeServer := setupLiveServer(t)
+	defer closeServer()
+
+	config, err := iac.Parse(strings.NewReader(testConfig))
+	require.NoError(t, err)
+
+	plan, err := iac.BuildPlan(context.Background(), client, config, &iac.PlanOptions{Prune: true})
+	require.NoError(t, err)
+
+	last := plan.Changes[len(plan.Changes)-1]
+	assert.Equal(t, iac.ActionDelete, last.Action)
+	assert.Equal(t, "pullzone.legacy", last.Address, "Pruned pull zones should be deleted last")
+}
+
+func TestBuildPlan_UnknownPullZoneReference(t *testing.T) {
+	client, _, closeServer := setupLiveServer(t)
+	defer closeServer()
+
+	config, err := iac.Parse(strings.NewReader("dnsZones:\n  - domain: example.com\n    records:\n      - {name: cdn, type: 7, pullZone: missing}\n"))
+	require.NoError(t, err)
+
+	_, err = iac.BuildPlan(context.Background(), client, config, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown pull zone")
+}
+
+func TestBuildPlan_NoChanges(t *testing.T) {
+	client, _, closeServer := setupLiveServer(t)
+	defer closeServer()
+
+	config, err := iac.Parse(strings.NewReader("pullZones:\n  - name: site\n    originUrl: https://origin.example.com\n"))
+	require.NoError(t, err)
+
+	plan, err := iac.BuildPlan(context.Background(), client, config, nil)
+	require.NoError(t, err)
+	assert.False(t, plan.HasChanges())
+	assert.Contains(t, plan.String(), "No changes")
+}
+
+func TestBuildPlan_RecordTtlOmitted(t *testing.T) {
+	client, _, closeServer := setupLiveServer(t)
+	defer closeServer()
+
+	config, err := iac.Parse(strings.NewReader("dnsZones:\n  - domain: example.com\n    records:\n      - {name: www, type: 0, value: 192.0.2.1}\n"))
+	require.NoError(t, err)
+
+	plan, err := iac.BuildPlan(context.Background(), client, config, nil)
+	require.NoError(t, err)
+	for _, change := range plan.Changes {
+		assert.NotEqual(t, "dnszone.example.com.record[www 0 192.0.2.1]", change.Address, "An omitted TTL should leave the live TTL unchanged")
+	}
+}
+
+func TestBuildPlan_TypeOmitted(t *testing.T) {
+	client, _, closeServer := setupLiveServer(t)
+	defer closeServer()
+
+	config, err := iac.Parse(strings.NewReader("pullZones:\n  - name: legacy\n    originUrl: https://legacy.example.com\n"))
+	require.NoError(t, err)
+	assert.Nil(t, config.PullZones[0].Type)
+
+	plan, err := iac.BuildPlan(context.Background(), client, config, nil)
+	require.NoError(t, err)
+	assert.False(t, plan.HasChanges(), "A Volume zone should not be changed when the configuration has no type")
+
+	config, err = iac.Parse(strings.NewReader("pullZones:\n  - name: legacy\n    originUrl: https://legacy.example.com\n    type: premium\n"))
+	require.NoError(t, err)
+
+	plan, err = iac.BuildPlan(context.Background(), client, config, nil)
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	assert.Equal(t, []iac.FieldChange{{Field: "Type", Old: resources.PullZoneTypeVolume, New: resources.PullZoneTypePremium}}, plan.Changes[0].Fields)
+}
+
+func TestApply_Success(t *testing.T) {
+	client, state, closeServer := setupLiveServer(t)
+	defer closeServer()
+
+	config, err := iac.Parse(strings.NewReader(testConfig))
+	require.NoError(t, err)
+
+	plan, err := iac.BuildPlan(context.Background(), client, config, nil)
+	require.NoError(t, err)
+
+	result, err := iac.Apply(context.Background(), client, plan)
+	require.NoError(t, err, "Apply should not return an error")
+	assert.Len(t, result.Applied, len(plan.Changes))
+	assert.Nil(t, result.Failed)
+
+	assert.Equal(t, []string{
+		"POST /pullzone/1",
+		"POST /pullzone",
+		"DELETE /pullzone/1/removeHostname",
+		"POST /pullzone/1/addHostname",
+		"DELETE /dnszone/10/records/101",
+		"POST /dnszone/10/records/100",
+		"PUT /dnszone/10/records",
+		"PUT /dnszone/10/records",
+	}, state.requests)
+
+	// Only the changed setting should be sent for the existing pull zone
+	assert.JSONEq(t, `{"CacheControlMaxAgeOverride": 3600}`, state.bodies["POST /pullzone/1"])
+
+	// The PullZone record should link the zone created earlier in the same apply
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(state.bodies["PUT /dnszone/10/records"]), &record))
+	assert.Equal(t, float64(3), record["PullZoneId"])
+	assert.Equal(t, float64(7), record["Type"])
+}
@@ -36,3 +36,25 @@ func AssertRequestMethod(t *testing.T, r *http.Request, method string) {
 func AssertRequestPath(t *testing.T, r *http.Request, path string) {
 	assert.Equal(t, path, r.URL.Path, "Request should have path %s but got %s", path, r.URL.Path)
 }
+
+// MockRouter creates a new test server that dispatches requests to handlers by method and path.
+// Routes use http.ServeMux patterns such as "GET /pullzone/{id}"; unmatched requests fail the test.
+func MockRouter(t *testing.T, routes map[string]http.HandlerFunc) *httptest.Server {
+	mux := http.NewServeMux()
+	for pattern, handler := range routes {
+		mux.HandleFunc(pattern, handler)
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+// RespondJSON writes a JSON response with the given status code and body
+func RespondJSON(w http.ResponseWriter, statusCode int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	fmt.Fprintln(w, body)
+}
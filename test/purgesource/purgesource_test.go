@@ -0,0 +1,108 @@
+package purgesource
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go/purgesource"
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+const testSitemap = `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>https://example.com/</loc><lastmod>2024-01-01</lastmod></url>
+  <url><loc> https://example.com/about </loc></url>
+</urlset>`
+
+func testZone() *resources.PullZone {
+	return &resources.PullZone{
+		Hostnames: []resources.Hostname{
+			{Value: "site.b-cdn.net", IsSystemHostname: true},
+			{Value: "example.com"},
+			{Value: "www.example.com"},
+		},
+	}
+}
+
+func TestParseSitemap(t *testing.T) {
+	urls, sitemaps, err := purgesource.ParseSitemap(strings.NewReader(testSitemap))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/", "https://example.com/about"}, urls)
+	assert.Empty(t, sitemaps)
+
+	_, _, err = purgesource.ParseSitemap(strings.NewReader(`<html></html>`))
+	assert.Error(t, err)
+}
+
+func TestFetchSitemap_Index(t *testing.T) {
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	gz.Write([]byte(`<urlset><url><loc>https://example.com/blog/post-1</loc></url></urlset>`))
+	gz.Close()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sitemap.xml":
+			w.Write([]byte(`<sitemapindex>
+				<sitemap><loc>` + server.URL + `/pages.xml</loc></sitemap>
+				<sitemap><loc>` + server.URL + `/blog.xml.gz</loc></sitemap>
+				<sitemap><loc>` + server.URL + `/sitemap.xml</loc></sitemap>
+			</sitemapindex>`))
+		case "/pages.xml":
+			w.Write([]byte(testSitemap))
+		case "/blog.xml.gz":
+			w.Write(gzipped.Bytes())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	urls, err := purgesource.FetchSitemap(context.Background(), server.Client(), server.URL+"/sitemap.xml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/", "https://example.com/about", "https://example.com/blog/post-1"}, urls)
+
+	_, err = purgesource.FetchSitemap(context.Background(), server.Client(), server.URL+"/missing.xml")
+	assert.ErrorContains(t, err, "404")
+}
+
+func TestURLsForPaths(t *testing.T) {
+	urls := purgesource.URLsForPaths(testZone(), []string{
+		"public/index.html",
+		"public/blog/my post.html",
+		"src/main.go",
+	}, &purgesource.PathOptions{Root: "public/", IndexFiles: []string{"index.html"}})
+
+	assert.Equal(t, []string{
+		"https://example.com/index.html",
+		"https://example.com/",
+		"https://example.com/blog/my%20post.html",
+		"https://www.example.com/index.html",
+		"https://www.example.com/",
+		"https://www.example.com/blog/my%20post.html",
+	}, urls)
+
+	urls = purgesource.URLsForPaths(testZone(), []string{"app.js"}, &purgesource.PathOptions{IncludeSystemHostnames: true, Scheme: "http"})
+	assert.Equal(t, []string{"http://site.b-cdn.net/app.js", "http://example.com/app.js", "http://www.example.com/app.js"}, urls)
+}
+
+func TestWildcardURLs(t *testing.T) {
+	assert.Equal(t, []string{"https://example.com/assets/img/*", "https://www.example.com/assets/img/*"},
+		purgesource.WildcardURLs(testZone(), "/assets/img/", nil))
+	assert.Equal(t, []string{"https://example.com/*", "https://www.example.com/*"},
+		purgesource.WildcardURLs(testZone(), "", nil))
+}
+
+func TestParsePathList(t *testing.T) {
+	paths, err := purgesource.ParsePathList(strings.NewReader("public/a.html\n\nM\tpublic/b.html\nR087\tpublic/old.html\tpublic/new.html\nD\tpublic/gone.html\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"public/a.html", "public/b.html", "public/old.html", "public/new.html", "public/gone.html"}, paths)
+}
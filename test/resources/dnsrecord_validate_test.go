@@ -0,0 +1,152 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+// validationFields returns the invalid field names of a validation error
+func validationFields(t *testing.T, err error) []string {
+	var validationErr *common.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	var fields []string
+	for _, fieldError := range validationErr.Errors {
+		fields = append(fields, fieldError.Field)
+	}
+	return fields
+}
+
+func TestAddDNSRecordOptions_Validate_Valid(t *testing.T) {
+	records := []resources.AddDNSRecordOptions{
+		{Type: resources.DNSRecordTypeA, Name: "@", Value: "192.0.2.1"},
+		{Type: resources.DNSRecordTypeAAAA, Name: "www", Value: "2001:db8::1", Ttl: 300},
+		{Type: resources.DNSRecordTypeCNAME, Name: "*.app", Value: "app.example.net."},
+		{Type: resources.DNSRecordTypeCNAME, Name: "www", Value: "@"},
+		{Type: resources.DNSRecordTypeFlatten, Name: "", Value: "@"},
+		{Type: resources.DNSRecordType(42), Name: "new", Value: "anything"},
+		{Type: resources.DNSRecordTypeMX, Name: "", Value: "mx.example.com", Priority: 10},
+		{Type: resources.DNSRecordTypeMX, Name: "", Value: "mail.example.com.", Priority: 0},
+		{Type: resources.DNSRecordTypeSRV, Name: "_xmpp._tcp", Value: "xmpp.example.com", Priority: 5, Port: 0},
+		{Type: resources.DNSRecordTypeSRV, Name: "_imap._tcp", Value: "."},
+		{Type: resources.DNSRecordTypeSRV, Name: "_sip._tcp", Value: "sip.example.com", Priority: 10, Weight: 5, Port: 5060},
+		{Type: resources.DNSRecordTypeCAA, Name: "", Value: "letsencrypt.org", Tag: "issue"},
+		{Type: resources.DNSRecordTypeCAA, Name: "", Value: "mailto:security@example.com", Tag: "iodef", Flags: 128},
+		{Type: resources.DNSRecordTypeTXT, Name: "_dmarc", Value: strings.Repeat("v", 1000)},
+		{Type: resources.DNSRecordTypeRedirect, Name: "old", Value: "https://example.com/new"},
+		{Type: resources.DNSRecordTypePullZone, Name: "cdn", PullZoneId: 12345},
+	}
+
+	for _, record := range records {
+		assert.NoError(t, record.Validate(), "%s record %q should be valid", record.Name, record.Value)
+	}
+}
+
+func TestAddDNSRecordOptions_Validate_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		record resources.AddDNSRecordOptions
+		fields []string
+	}{
+		{"ipv6 in A", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypeA, Name: "www", Value: "2001:db8::1"}, []string{"Value"}},
+		{"ipv4 in AAAA", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypeAAAA, Name: "www", Value: "192.0.2.1"}, []string{"Value"}},
+		{"cname at apex", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypeCNAME, Name: "@", Value: "example.net"}, []string{"Name"}},
+		{"bad hostname", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypeCNAME, Name: "www", Value: "bad host"}, []string{"Value"}},
+		{"mx priority out of range", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypeMX, Value: "mx.example.com", Priority: -1}, []string{"Priority"}},
+		{"mx root target", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypeMX, Value: "."}, []string{"Value"}},
+		{"srv", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypeSRV, Name: "sip", Value: "sip.example.com", Priority: 70000}, []string{"Priority", "Name"}},
+		{"srv port out of range", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypeSRV, Name: "_sip._tcp", Value: "sip.example.com", Port: 70000}, []string{"Port"}},
+		{"caa", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypeCAA, Value: "x", Tag: "bogus", Flags: 1}, []string{"Tag", "Flags"}},
+		{"caa iodef", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypeCAA, Value: "security@example.com", Tag: "iodef"}, []string{"Value"}},
+		{"long txt", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypeTXT, Value: strings.Repeat("x", 70000)}, []string{"Value"}},
+		{"ttl", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypeA, Value: "192.0.2.1", Ttl: 5}, []string{"Ttl"}},
+		{"unknown type", resources.AddDNSRecordOptions{Type: -1, Value: "x"}, []string{"Type"}},
+		{"ns at apex target", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypeNS, Name: "sub", Value: "@"}, []string{"Value"}},
+		{"pull zone", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypePullZone, Name: "cdn"}, []string{"PullZoneId"}},
+		{"bad name", resources.AddDNSRecordOptions{Type: resources.DNSRecordTypeA, Name: "-www", Value: "192.0.2.1"}, []string{"Name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.record.Validate()
+			require.Error(t, err)
+			assert.Equal(t, tt.fields, validationFields(t, err))
+		})
+	}
+}
+
+func TestUpdateDNSRecordOptions_Validate(t *testing.T) {
+	// Only the fields that are set are checked
+	assert.NoError(t, resources.UpdateDNSRecordOptions{Id: 1, Ttl: common.Ptr(int32(0))}.Validate())
+	assert.NoError(t, resources.UpdateDNSRecordOptions{Id: 1, Value: common.Ptr("anything")}.Validate())
+
+	err := resources.UpdateDNSRecordOptions{
+		Type:  common.Ptr(resources.DNSRecordTypeA),
+		Value: common.Ptr("not-an-ip"),
+		Ttl:   common.Ptr(int32(1)),
+	}.Validate()
+	require.Error(t, err)
+	assert.Equal(t, []string{"Id", "Ttl", "Value"}, validationFields(t, err))
+}
+
+func TestSplitTXTValue(t *testing.T) {
+	assert.Equal(t, []string{"short"}, resources.SplitTXTValue("short"))
+
+	chunks := resources.SplitTXTValue(strings.Repeat("a", 600))
+	require.Len(t, chunks, 3)
+	assert.Len(t, chunks[0], 255)
+	assert.Len(t, chunks[1], 255)
+	assert.Len(t, chunks[2], 90)
+}
+
+func TestDNSZoneService_AddRecord_ValidationError(t *testing.T) {
+	// Create a mock server that fails the test if it is called
+	server := test.MockServer(t, http.StatusCreated, `{}`, func(r *http.Request) {
+		t.Error("Invalid records should not be sent to the API")
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	_, err := client.DNSZone.AddRecord(context.Background(), 123, resources.AddDNSRecordOptions{
+		Type:  resources.DNSRecordTypeA,
+		Name:  "www",
+		Value: "2001:db8::1",
+	})
+	require.Error(t, err)
+	assert.Equal(t, []string{"Value"}, validationFields(t, err))
+
+	err = client.DNSZone.UpdateRecord(context.Background(), 123, 456, resources.UpdateDNSRecordOptions{
+		Ttl: common.Ptr(int32(-1)),
+	})
+	require.Error(t, err)
+	assert.Equal(t, []string{"Ttl"}, validationFields(t, err), "the record ID argument should fill in a missing Id")
+}
+
+func TestDNSZoneService_UpdateRecord_FillsId(t *testing.T) {
+	server := test.MockServer(t, http.StatusNoContent, ``, func(r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, float64(456), body["Id"])
+		assert.Equal(t, "@", body["Value"])
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	err := client.DNSZone.UpdateRecord(context.Background(), 123, 456, resources.UpdateDNSRecordOptions{
+		Type:  common.Ptr(resources.DNSRecordTypeCNAME),
+		Value: common.Ptr("@"),
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,156 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+func setupRecordsServer(t *testing.T) (*bunnynet.Client, func()) {
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /dnszone/10": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{
+				"Id": 10,
+				"Domain": "example.com",
+				"Records": [
+					{"Id": 1, "Type": 0, "Name": "", "Value": "192.0.2.1", "MonitorStatus": 1},
+					{"Id": 2, "Type": 0, "Name": "www", "Value": "192.0.2.2", "MonitorStatus": 2},
+					{"Id": 3, "Type": 2, "Name": "API", "Value": "api.example.net"},
+					{"Id": 4, "Type": 3, "Name": "", "Value": "v=spf1 -all", "Disabled": true}
+				]
+			}`)
+		},
+		"GET /dnszone/404": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusNotFound, `{"ErrorKey": "dnszone.not_found", "Field": "Id", "Message": "The DNS zone was not found"}`)
+		},
+		"GET /dnszone": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "1000", r.URL.Query().Get("perPage"))
+			test.RespondJSON(w, http.StatusOK, `{
+				"Items": [
+					{"Id": 10, "Domain": "example.com"},
+					{"Id": 11, "Domain": "eu.example.com"},
+					{"Id": 12, "Domain": "ample.com"}
+				],
+				"CurrentPage": 1,
+				"TotalItems": 3,
+				"HasMoreItems": false
+			}`)
+		},
+	})
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+	return client, server.Close
+}
+
+// recordIds returns the IDs of the records
+func recordIds(records []resources.DNSRecord) []int64 {
+	ids := make([]int64, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.Id)
+	}
+	return ids
+}
+
+func TestDNSZoneService_ListRecords_Filter(t *testing.T) {
+	client, closeServer := setupRecordsServer(t)
+	defer closeServer()
+
+	tests := []struct {
+		name   string
+		filter *resources.DNSRecordFilter
+		ids    []int64
+	}{
+		{"all", nil, []int64{1, 2, 3, 4}},
+		{"apex", &resources.DNSRecordFilter{Name: common.Ptr("@")}, []int64{1, 4}},
+		{"name case-insensitive", &resources.DNSRecordFilter{Name: common.Ptr("api")}, []int64{3}},
+		{"types", &resources.DNSRecordFilter{Types: []resources.DNSRecordType{resources.DNSRecordTypeCNAME, resources.DNSRecordTypeTXT}}, []int64{3, 4}},
+		{"disabled", &resources.DNSRecordFilter{Disabled: common.Ptr(false), Types: []resources.DNSRecordType{resources.DNSRecordTypeTXT}}, []int64{}},
+		{"monitor status", &resources.DNSRecordFilter{MonitorStatus: common.Ptr(resources.MonitorStatusOffline)}, []int64{2}},
+		{"value", &resources.DNSRecordFilter{Value: common.Ptr("192.0.2.1")}, []int64{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := client.DNSZone.ListRecords(context.Background(), 10, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ids, recordIds(records))
+		})
+	}
+}
+
+func TestDNSZoneService_GetRecord(t *testing.T) {
+	client, closeServer := setupRecordsServer(t)
+	defer closeServer()
+
+	record, err := client.DNSZone.GetRecord(context.Background(), 10, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "api.example.net", record.Value)
+
+	_, err = client.DNSZone.GetRecord(context.Background(), 10, 99)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+
+	// API 404 responses also match ErrNotFound
+	_, err = client.DNSZone.GetRecord(context.Background(), 404, 1)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+	var apiErr *common.ErrorResponse
+	assert.True(t, errors.As(err, &apiErr))
+}
+
+func TestDNSZoneService_FindRecord(t *testing.T) {
+	client, closeServer := setupRecordsServer(t)
+	defer closeServer()
+
+	record, err := client.DNSZone.FindRecord(context.Background(), 10, "www", resources.DNSRecordTypeA)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), record.Id)
+
+	_, err = client.DNSZone.FindRecord(context.Background(), 10, "www", resources.DNSRecordTypeAAAA)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+	assert.Contains(t, err.Error(), `AAAA record "www"`)
+}
+
+func TestDNSZoneService_FindZoneForDomain(t *testing.T) {
+	client, closeServer := setupRecordsServer(t)
+	defer closeServer()
+
+	tests := []struct {
+		domain string
+		zoneId int64
+	}{
+		{"api.example.com", 10},
+		{"example.com.", 10},
+		{"WWW.EU.example.com", 11},
+		{"eu.example.com", 11},
+	}
+	for _, tt := range tests {
+		zone, err := client.DNSZone.FindZoneForDomain(context.Background(), tt.domain)
+		require.NoError(t, err, tt.domain)
+		assert.Equal(t, tt.zoneId, zone.Id, tt.domain)
+	}
+
+	_, err := client.DNSZone.FindZoneForDomain(context.Background(), "notexample.com")
+	assert.ErrorIs(t, err, common.ErrNotFound, "Zones should only match on label boundaries")
+}
+
+func TestDNSZone_RecordName(t *testing.T) {
+	zone := &resources.DNSZone{Domain: "example.com"}
+
+	name, ok := zone.RecordName("api.eu.example.com.")
+	assert.True(t, ok)
+	assert.Equal(t, "api.eu", name)
+
+	name, ok = zone.RecordName("EXAMPLE.com")
+	assert.True(t, ok)
+	assert.Equal(t, "", name)
+
+	_, ok = zone.RecordName("example.org")
+	assert.False(t, ok)
+}
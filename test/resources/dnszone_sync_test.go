@@ -0,0 +1,205 @@
+package resources
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+// syncRecorder records the mutating requests sent during a sync
+type syncRecorder struct {
+	mu       sync.Mutex
+	requests []string
+	bodies   map[string]string
+}
+
+func (s *syncRecorder) handler(statusCode int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+
+		s.mu.Lock()
+		key := r.Method + " " + r.URL.Path
+		s.requests = append(s.requests, key)
+		s.bodies[key] = string(data)
+		s.mu.Unlock()
+
+		if body == "" {
+			w.WriteHeader(statusCode)
+			return
+		}
+		test.RespondJSON(w, statusCode, body)
+	}
+}
+
+func setupSyncServer(t *testing.T) (*bunnynet.Client, *syncRecorder, func()) {
+	recorder := &syncRecorder{bodies: make(map[string]string)}
+
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /dnszone/10": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{
+				"Id": 10,
+				"Domain": "example.com",
+				"Records": [
+					{"Id": 1, "Type": 0, "Name": "www", "Value": "192.0.2.1", "Ttl": 300},
+					{"Id": 2, "Type": 2, "Name": "api", "Value": "API.example.net.", "Ttl": 300},
+					{"Id": 3, "Type": 3, "Name": "", "Value": "stale", "Ttl": 300},
+					{"Id": 4, "Type": 0, "Name": "www", "Value": "192.0.2.1", "Ttl": 300}
+				]
+			}`)
+		},
+		"POST /dnszone/10/records/1":   recorder.handler(http.StatusNoContent, ""),
+		"DELETE /dnszone/10/records/3": recorder.handler(http.StatusNoContent, ""),
+		"DELETE /dnszone/10/records/4": recorder.handler(http.StatusNoContent, ""),
+		"PUT /dnszone/10/records":      recorder.handler(http.StatusCreated, `{"Id": 5, "Type": 4, "Name": "", "Value": "mx.example.com"}`),
+	})
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+	return client, recorder, server.Close
+}
+
+var syncDesired = []resources.AddDNSRecordOptions{
+	{Type: resources.DNSRecordTypeA, Name: "www", Value: "192.0.2.1", Ttl: 600},
+	{Type: resources.DNSRecordTypeCNAME, Name: "api", Value: "api.example.net", Ttl: 300},
+	{Type: resources.DNSRecordTypeMX, Name: "@", Value: "mx.example.com", Priority: 10, Ttl: 300},
+}
+
+func TestDNSZoneService_Sync_Success(t *testing.T) {
+	client, recorder, closeServer := setupSyncServer(t)
+	defer closeServer()
+
+	report, err := client.DNSZone.Sync(context.Background(), 10, syncDesired, &resources.SyncOptions{Concurrency: 2})
+	require.NoError(t, err, "Sync should not return an error")
+
+	added, updated, deleted := report.Counts()
+	assert.Equal(t, 1, added)
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, 2, deleted)
+	assert.Equal(t, 1, report.Unchanged, "The CNAME should match case-insensitively")
+	assert.Empty(t, report.Failed())
+
+	// Deletions run before updates, and updates before additions
+	assert.Equal(t, resources.SyncActionDelete, report.Changes[0].Action)
+	assert.Equal(t, resources.SyncActionDelete, report.Changes[1].Action)
+	assert.Equal(t, resources.SyncActionUpdate, report.Changes[2].Action)
+	assert.Equal(t, []string{"Ttl"}, report.Changes[2].Fields)
+	assert.Equal(t, resources.SyncActionAdd, report.Changes[3].Action)
+	assert.Equal(t, int64(5), report.Changes[3].Record.Id)
+
+	requests := append([]string(nil), recorder.requests...)
+	sort.Strings(requests[:2])
+	assert.Equal(t, []string{
+		"DELETE /dnszone/10/records/3",
+		"DELETE /dnszone/10/records/4",
+		"POST /dnszone/10/records/1",
+		"PUT /dnszone/10/records",
+	}, requests)
+
+	// Only the changed field should be sent in the update
+	assert.JSONEq(t, `{"Id": 1, "Ttl": 600}`, recorder.bodies["POST /dnszone/10/records/1"])
+}
+
+func TestDNSZoneService_Sync_DryRun(t *testing.T) {
+	client, recorder, closeServer := setupSyncServer(t)
+	defer closeServer()
+
+	report, err := client.DNSZone.Sync(context.Background(), 10, syncDesired, &resources.SyncOptions{DryRun: true})
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Len(t, report.Changes, 4)
+	assert.Empty(t, recorder.requests, "A dry run should not send mutating requests")
+}
+
+func TestDNSZoneService_Sync_NoDelete(t *testing.T) {
+	client, recorder, closeServer := setupSyncServer(t)
+	defer closeServer()
+
+	report, err := client.DNSZone.Sync(context.Background(), 10, syncDesired, &resources.SyncOptions{NoDelete: true})
+	require.NoError(t, err)
+
+	_, _, deleted := report.Counts()
+	assert.Equal(t, 0, deleted)
+	assert.Len(t, report.Kept, 2)
+	assert.NotContains(t, recorder.requests, "DELETE /dnszone/10/records/3")
+}
+
+func TestDNSZoneService_Sync_PartialFailure(t *testing.T) {
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /dnszone/10": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{"Id": 10, "Domain": "example.com", "Records": []}`)
+		},
+		"PUT /dnszone/10/records": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusBadRequest, `{"ErrorKey": "dnsrecord.invalid", "Field": "Value", "Message": "Invalid value"}`)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	report, err := client.DNSZone.Sync(context.Background(), 10, syncDesired[:1], nil)
+	require.Error(t, err, "Sync should return an error when a change fails")
+	require.NotNil(t, report, "The report should be returned with the error")
+	assert.Contains(t, err.Error(), "add www 0 192.0.2.1")
+	assert.Contains(t, err.Error(), "dnsrecord.invalid")
+	assert.Len(t, report.Failed(), 1)
+}
+
+func TestDNSZoneService_Sync_DuplicateDesired(t *testing.T) {
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL("http://127.0.0.1:0"))
+
+	desired := []resources.AddDNSRecordOptions{
+		{Type: resources.DNSRecordTypeA, Name: "", Value: "192.0.2.1"},
+		{Type: resources.DNSRecordTypeA, Name: "@", Value: "192.0.2.1"},
+	}
+	_, err := client.DNSZone.Sync(context.Background(), 10, desired, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "declared more than once")
+}
+
+func TestDNSZoneService_Sync_PullZoneTarget(t *testing.T) {
+	recorder := &syncRecorder{bodies: make(map[string]string)}
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /dnszone/10": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{
+				"Id": 10,
+				"Domain": "example.com",
+				"Records": [
+					{"Id": 1, "Type": 7, "Name": "cdn", "Value": "", "PullZoneId": 100, "Ttl": 300},
+					{"Id": 2, "Type": 11, "Name": "edge", "Value": "edge-7.b-cdn.net", "ScriptId": 7, "Ttl": 300},
+					{"Id": 3, "Type": 11, "Name": "api", "Value": "api-9.b-cdn.net", "ScriptId": 9, "Ttl": 300}
+				]
+			}`)
+		},
+		"POST /dnszone/10/records/1": recorder.handler(http.StatusNoContent, ""),
+		"POST /dnszone/10/records/3": recorder.handler(http.StatusNoContent, ""),
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	desired := []resources.AddDNSRecordOptions{
+		{Type: resources.DNSRecordTypePullZone, Name: "cdn", PullZoneId: 200, Ttl: 300},
+		{Type: resources.DNSRecordTypeScript, Name: "edge", ScriptId: 7, Ttl: 300},
+		{Type: resources.DNSRecordTypeScript, Name: "api", ScriptId: 10, Ttl: 300},
+	}
+	report, err := client.DNSZone.Sync(context.Background(), 10, desired, nil)
+	require.NoError(t, err)
+
+	// PullZone and Script records are matched without their value and updated in place
+	require.Len(t, report.Changes, 2)
+	for _, change := range report.Changes {
+		assert.Equal(t, resources.SyncActionUpdate, change.Action)
+	}
+	assert.Equal(t, 1, report.Unchanged)
+	assert.JSONEq(t, `{"Id": 1, "PullZoneId": 200}`, recorder.bodies["POST /dnszone/10/records/1"])
+	assert.JSONEq(t, `{"Id": 3, "ScriptId": 10}`, recorder.bodies["POST /dnszone/10/records/3"])
+}
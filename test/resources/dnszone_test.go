@@ -2,6 +2,7 @@ package resources
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"testing"
 
@@ -259,13 +260,13 @@ func TestDNSZoneService_Update_Success(t *testing.T) {
 
 	// Call the Update method
 	options := resources.UpdateDNSZoneOptions{
-		CustomNameserversEnabled:      true,
-		Nameserver1:                   "ns1.example.com",
-		Nameserver2:                   "ns2.example.com",
-		SoaEmail:                      "admin@example.com",
-		LoggingEnabled:                true,
-		LogAnonymizationType:          resources.LogAnonymizationTypeDrop,
-		LoggingIPAnonymizationEnabled: true,
+		CustomNameserversEnabled:      common.Ptr(true),
+		Nameserver1:                   common.Ptr("ns1.example.com"),
+		Nameserver2:                   common.Ptr("ns2.example.com"),
+		SoaEmail:                      common.Ptr("admin@example.com"),
+		LoggingEnabled:                common.Ptr(true),
+		LogAnonymizationType:          common.Ptr(resources.LogAnonymizationTypeDrop),
+		LoggingIPAnonymizationEnabled: common.Ptr(true),
 	}
 	dnsZone, err := client.DNSZone.Update(context.Background(), 123, options)
 	assert.NoError(t, err, "Update should not return an error")
@@ -403,15 +404,73 @@ func TestDNSZoneService_UpdateRecord_Success(t *testing.T) {
 	// Call the UpdateRecord method
 	options := resources.UpdateDNSRecordOptions{
 		Id:    456,
-		Type:  resources.DNSRecordTypeA,
-		Ttl:   7200,
-		Value: "192.0.2.2",
-		Name:  "www",
+		Type:  common.Ptr(resources.DNSRecordTypeA),
+		Ttl:   common.Ptr(int32(7200)),
+		Value: common.Ptr("192.0.2.2"),
+		Name:  common.Ptr("www"),
 	}
 	err := client.DNSZone.UpdateRecord(context.Background(), 123, 456, options)
 	assert.NoError(t, err, "UpdateRecord should not return an error")
 }
 
+func TestDNSZoneService_UpdateRecord_ZeroValues(t *testing.T) {
+	// Create a mock server
+	server := test.MockServer(t, http.StatusNoContent, ``, func(r *http.Request) {
+		test.AssertRequestMethod(t, r, http.MethodPost)
+		test.AssertRequestPath(t, r, "/dnszone/123/records/456")
+
+		// Explicit zero values must be sent while unset fields are omitted
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"Id": 456, "Ttl": 0, "Disabled": false, "Accelerated": false}`, string(body))
+	})
+	defer server.Close()
+
+	// Create a client that uses the mock server
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	// Call the UpdateRecord method
+	options := resources.UpdateDNSRecordOptions{
+		Id:          456,
+		Ttl:         common.Ptr(int32(0)),
+		Disabled:    common.Ptr(false),
+		Accelerated: common.Ptr(false),
+	}
+	err := client.DNSZone.UpdateRecord(context.Background(), 123, 456, options)
+	assert.NoError(t, err, "UpdateRecord should not return an error")
+}
+
+func TestDNSZoneService_Update_ZeroValues(t *testing.T) {
+	// Create a mock server
+	server := test.MockServer(t, http.StatusOK, `{
+		"Id": 123,
+		"Domain": "example.com",
+		"CustomNameserversEnabled": false,
+		"LoggingEnabled": false
+	}`, func(r *http.Request) {
+		test.AssertRequestMethod(t, r, http.MethodPost)
+		test.AssertRequestPath(t, r, "/dnszone/123")
+
+		// Explicit zero values must be sent while unset fields are omitted
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"CustomNameserversEnabled": false, "LoggingEnabled": false}`, string(body))
+	})
+	defer server.Close()
+
+	// Create a client that uses the mock server
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	// Call the Update method
+	dnsZone, err := client.DNSZone.Update(context.Background(), 123, resources.UpdateDNSZoneOptions{
+		CustomNameserversEnabled: common.Ptr(false),
+		LoggingEnabled:           common.Ptr(false),
+	})
+	assert.NoError(t, err, "Update should not return an error")
+	assert.False(t, dnsZone.CustomNameserversEnabled)
+	assert.False(t, dnsZone.LoggingEnabled)
+}
+
 func TestDNSZoneService_DeleteRecord_Success(t *testing.T) {
 	// Create a mock server
 	server := test.MockServer(t, http.StatusNoContent, ``, func(r *http.Request) {
@@ -0,0 +1,146 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDNSRecordType_String(t *testing.T) {
+	assert.Equal(t, "MX", resources.DNSRecordTypeMX.String())
+	assert.Equal(t, "PullZone", resources.DNSRecordTypePullZone.String())
+	assert.Equal(t, "DNSRecordType(42)", resources.DNSRecordType(42).String())
+	assert.Equal(t, "Type: MX", fmt.Sprintf("Type: %v", resources.DNSRecordTypeMX))
+}
+
+func TestParseDNSRecordType(t *testing.T) {
+	tests := []struct {
+		input string
+		want  resources.DNSRecordType
+	}{
+		{"MX", resources.DNSRecordTypeMX},
+		{"aaaa", resources.DNSRecordTypeAAAA},
+		{" cname ", resources.DNSRecordTypeCNAME},
+		{"7", resources.DNSRecordTypePullZone},
+	}
+	for _, tt := range tests {
+		got, err := resources.ParseDNSRecordType(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+
+	_, err := resources.ParseDNSRecordType("SSHFP")
+	assert.EqualError(t, err, `invalid DNSRecordType "SSHFP"`)
+	_, err = resources.ParseDNSRecordType("99")
+	assert.Error(t, err)
+}
+
+func TestDNSEnums_RoundTrip(t *testing.T) {
+	assert.Equal(t, "Geolocation", resources.SmartRoutingTypeGeolocation.String())
+	assert.Equal(t, "Offline", resources.MonitorStatusOffline.String())
+	assert.Equal(t, "Http", resources.MonitorTypeHTTP.String())
+	assert.Equal(t, "Drop", resources.LogAnonymizationTypeDrop.String())
+
+	monitorType, err := resources.ParseMonitorType("ping")
+	require.NoError(t, err)
+	assert.Equal(t, resources.MonitorTypePing, monitorType)
+
+	status, err := resources.ParseMonitorStatus("Online")
+	require.NoError(t, err)
+	assert.Equal(t, resources.MonitorStatusOnline, status)
+
+	routing, err := resources.ParseSmartRoutingType("latency")
+	require.NoError(t, err)
+	assert.Equal(t, resources.SmartRoutingTypeLatency, routing)
+
+	anonymization, err := resources.ParseLogAnonymizationType("OneDigit")
+	require.NoError(t, err)
+	assert.Equal(t, resources.LogAnonymizationTypeOneDigit, anonymization)
+}
+
+func TestDNSEnums_JSON(t *testing.T) {
+	// Enums stay numbers on the wire
+	data, err := json.Marshal(resources.AddDNSRecordOptions{
+		Type:             resources.DNSRecordTypeMX,
+		MonitorType:      resources.MonitorTypePing,
+		SmartRoutingType: resources.SmartRoutingTypeLatency,
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Type": 4, "MonitorType": 1, "SmartRoutingType": 1}`, string(data))
+
+	// Numbers and names are both accepted when decoding, and unknown numbers are kept
+	var record resources.DNSRecord
+	require.NoError(t, json.Unmarshal([]byte(`{"Type": "CNAME", "MonitorStatus": 2, "MonitorType": 99}`), &record))
+	assert.Equal(t, resources.DNSRecordTypeCNAME, record.Type)
+	assert.Equal(t, resources.MonitorStatusOffline, record.MonitorStatus)
+	assert.Equal(t, resources.MonitorType(99), record.MonitorType)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"Type": "BOGUS"}`), &record))
+}
+
+func TestDNSEnums_YAML(t *testing.T) {
+	type config struct {
+		Type    resources.DNSRecordType     `yaml:"type"`
+		Monitor resources.MonitorType       `yaml:"monitor"`
+		Routing *resources.SmartRoutingType `yaml:"routing"`
+	}
+
+	var c config
+	require.NoError(t, yaml.Unmarshal([]byte("type: srv\nmonitor: 2\nrouting: Geolocation\n"), &c))
+	assert.Equal(t, resources.DNSRecordTypeSRV, c.Type)
+	assert.Equal(t, resources.MonitorTypeHTTP, c.Monitor)
+	assert.Equal(t, resources.SmartRoutingTypeGeolocation, *c.Routing)
+
+	out, err := yaml.Marshal(config{Type: resources.DNSRecordTypeTXT})
+	require.NoError(t, err)
+	assert.Equal(t, "type: TXT\nmonitor: None\nrouting: null\n", string(out))
+}
+
+func TestPullZoneEnums_RoundTrip(t *testing.T) {
+	assert.Equal(t, "Volume", resources.PullZoneTypeVolume.String())
+	assert.Equal(t, "StorageZone", resources.OriginTypeStorageZone.String())
+	assert.Equal(t, "OriginUrl", resources.OriginTypeURL.String())
+	assert.Equal(t, "JSON", resources.LogFormatJSON.String())
+	assert.Equal(t, "Plain", resources.LogForwardingFormatPlain.String())
+	assert.Equal(t, "TCPEncrypted", resources.LogForwardingProtocolTCPEncrypted.String())
+	assert.Equal(t, "OriginType(42)", resources.OriginType(42).String())
+
+	zoneType, err := resources.ParsePullZoneType("premium")
+	require.NoError(t, err)
+	assert.Equal(t, resources.PullZoneTypePremium, zoneType)
+
+	originType, err := resources.ParseOriginType("dnsaccelerate")
+	require.NoError(t, err)
+	assert.Equal(t, resources.OriginTypeDNSAccelerate, originType)
+
+	protocol, err := resources.ParseLogForwardingProtocol("3")
+	require.NoError(t, err)
+	assert.Equal(t, resources.LogForwardingProtocolDataDog, protocol)
+
+	_, err = resources.ParseLogFormat("xml")
+	assert.EqualError(t, err, `invalid LogFormat "xml"`)
+}
+
+func TestPullZoneEnums_JSON(t *testing.T) {
+	data, err := json.Marshal(resources.UpdatePullZoneOptions{
+		Type:                  common.Ptr(resources.PullZoneTypeVolume),
+		OriginType:            common.Ptr(resources.OriginTypeStorageZone),
+		LogForwardingProtocol: common.Ptr(resources.LogForwardingProtocolTCP),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Type": 1, "OriginType": 2, "LogForwardingProtocol": 1}`, string(data))
+
+	var zone resources.PullZone
+	require.NoError(t, json.Unmarshal([]byte(`{"Type": 1, "OriginType": "PushZone", "LogFormat": 1, "LogForwardingFormat": 0, "LogAnonymizationType": 1}`), &zone))
+	assert.Equal(t, resources.PullZoneTypeVolume, zone.Type)
+	assert.Equal(t, resources.OriginTypePushZone, zone.OriginType)
+	assert.Equal(t, resources.LogFormatJSON, zone.LogFormat)
+	assert.Equal(t, resources.LogForwardingFormatPlain, zone.LogForwardingFormat)
+	assert.Equal(t, resources.LogAnonymizationTypeDrop, zone.LogAnonymizationType)
+}
@@ -0,0 +1,228 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+func TestPullZoneService_Clone(t *testing.T) {
+	var mu sync.Mutex
+	var added resources.AddPullZoneOptions
+	var update map[string]interface{}
+	var rules []resources.AddOrUpdateEdgeRuleOptions
+	var hostnames []string
+	var forceSSL []string
+
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /pullzone/5": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{
+				"Id": 5,
+				"Name": "source",
+				"OriginUrl": "https://origin.example.com",
+				"Type": 1,
+				"ZoneSecurityKey": "secret",
+				"CacheControlMaxAgeOverride": 3600,
+				"EnableWebPVary": true,
+				"BlockedIps": ["192.0.2.1"],
+				"AllowedReferrers": ["example.com"],
+				"CnameDomain": "source.b-cdn.net",
+				"Hostnames": [
+					{"Value": "source.b-cdn.net", "IsSystemHostname": true},
+					{"Value": "old.example.com", "ForceSSL": true, "HasCertificate": true},
+					{"Value": "taken.example.com"}
+				],
+				"EdgeRules": [
+					{"Guid": "a", "ActionType": 0, "Description": "Force SSL", "Enabled": true, "Triggers": [{"Type": 0, "PatternMatches": ["*"]}]},
+					{"Guid": "b", "ActionType": 99, "Description": "Broken", "Enabled": true}
+				]
+			}`)
+		},
+		"POST /pullzone": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&added)
+			test.RespondJSON(w, http.StatusCreated, `{"Id": 9, "Name": "copy", "OriginUrl": "https://new.example.com", "Type": 1, "CnameDomain": "copy.b-cdn.net"}`)
+		},
+		"POST /pullzone/9": func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&update)
+			test.RespondJSON(w, http.StatusOK, `{"Id": 9, "Name": "copy"}`)
+		},
+		"POST /pullzone/9/edgerules/addOrUpdate": func(w http.ResponseWriter, r *http.Request) {
+			var options resources.AddOrUpdateEdgeRuleOptions
+			json.NewDecoder(r.Body).Decode(&options)
+			if options.ActionType == 99 {
+				test.RespondJSON(w, http.StatusBadRequest, `{"ErrorKey": "edgerule.validation", "Message": "Invalid action"}`)
+				return
+			}
+			mu.Lock()
+			rules = append(rules, options)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"POST /pullzone/9/addHostname": func(w http.ResponseWriter, r *http.Request) {
+			var options resources.AddHostnameOptions
+			json.NewDecoder(r.Body).Decode(&options)
+			if options.Hostname == "taken.example.com" {
+				test.RespondJSON(w, http.StatusBadRequest, `{"ErrorKey": "pullzone.hostname_taken", "Message": "Hostname in use"}`)
+				return
+			}
+			hostnames = append(hostnames, options.Hostname)
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"POST /pullzone/9/setForceSSL": func(w http.ResponseWriter, r *http.Request) {
+			var options resources.SetForceSSLOptions
+			json.NewDecoder(r.Body).Decode(&options)
+			forceSSL = append(forceSSL, options.Hostname)
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"GET /pullzone/9": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{"Id": 9, "Name": "copy", "EdgeRules": [{"Guid": "new"}]}`)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	report, err := client.PullZone.Clone(context.Background(), 5, "copy", &resources.CloneOptions{
+		OriginUrl: "https://new.example.com",
+		Hostnames: true,
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `EdgeRule "Broken"`)
+	assert.ErrorContains(t, err, "Hostname taken.example.com")
+
+	assert.Equal(t, "copy", added.Name)
+	assert.Equal(t, "https://new.example.com", added.OriginUrl)
+	assert.Equal(t, resources.PullZoneTypeVolume, added.Type)
+
+	assert.Equal(t, float64(3600), update["CacheControlMaxAgeOverride"])
+	assert.Equal(t, true, update["EnableWebPVary"])
+	assert.Equal(t, []interface{}{"192.0.2.1"}, update["BlockedIps"])
+	assert.Equal(t, []interface{}{"example.com"}, update["AllowedReferrers"])
+	assert.NotContains(t, update, "OriginUrl")
+	assert.NotContains(t, update, "ZoneSecurityKey")
+
+	require.Len(t, rules, 1)
+	assert.Empty(t, rules[0].Guid)
+	assert.Equal(t, "Force SSL", rules[0].Description)
+
+	assert.Equal(t, []string{"old.example.com"}, hostnames)
+	assert.Equal(t, []string{"old.example.com"}, forceSSL)
+
+	require.NotNil(t, report)
+	assert.Equal(t, int64(5), report.SourceId)
+	assert.Equal(t, int64(9), report.PullZone.Id)
+	assert.Len(t, report.PullZone.EdgeRules, 1)
+	assert.Equal(t, 1, report.EdgeRules)
+	assert.Equal(t, []string{"old.example.com"}, report.Hostnames)
+
+	var settings []string
+	for _, skipped := range report.Skipped {
+		settings = append(settings, skipped.Setting)
+	}
+	assert.ElementsMatch(t, []string{
+		"ZoneSecurityKey",
+		`EdgeRule "Broken"`,
+		"Hostname old.example.com certificate",
+		"Hostname taken.example.com",
+	}, settings)
+}
+
+func TestPullZoneService_CreateFromTemplate_AddFails(t *testing.T) {
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"POST /pullzone": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusBadRequest, `{"ErrorKey": "pullzone.name_taken", "Message": "Name taken"}`)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	report, err := client.PullZone.CreateFromTemplate(context.Background(), "copy", &resources.PullZone{OriginUrl: "https://origin.example.com"}, nil)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}
+
+func TestPullZoneService_CreateFromTemplate_PatchFails(t *testing.T) {
+	var rules int
+
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"POST /pullzone": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusCreated, `{"Id": 9, "Name": "copy", "OriginUrl": "https://origin.example.com"}`)
+		},
+		"POST /pullzone/9": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusBadRequest, `{"ErrorKey": "pullzone.validation", "Message": "Invalid setting"}`)
+		},
+		"POST /pullzone/9/edgerules/addOrUpdate": func(w http.ResponseWriter, r *http.Request) {
+			rules++
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"GET /pullzone/9": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{"Id": 9, "Name": "copy"}`)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	// A rejected settings update is reported as skipped and the edge rules are still copied
+	report, err := client.PullZone.CreateFromTemplate(context.Background(), "copy", &resources.PullZone{
+		OriginUrl:      "https://origin.example.com",
+		EnableWebPVary: true,
+		EdgeRules: []resources.EdgeRule{
+			{Guid: "a", ActionType: resources.EdgeRuleActionTypeForceSSL, Enabled: true, Triggers: []resources.EdgeRuleTrigger{{PatternMatches: []string{"*"}}}},
+		},
+	}, nil)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "Settings")
+
+	require.NotNil(t, report)
+	assert.Equal(t, int64(9), report.PullZone.Id)
+	assert.Equal(t, 1, report.EdgeRules)
+	assert.Equal(t, 1, rules)
+	require.Len(t, report.Skipped, 1)
+	assert.Equal(t, "Settings", report.Skipped[0].Setting)
+	assert.Error(t, report.Skipped[0].Err)
+}
+
+func TestPullZoneService_CreateFromTemplate_EdgeRuleFails(t *testing.T) {
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"POST /pullzone": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusCreated, `{"Id": 9, "Name": "copy"}`)
+		},
+		"POST /pullzone/9": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{"Id": 9, "Name": "copy"}`)
+		},
+		"POST /pullzone/9/edgerules/addOrUpdate": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusBadRequest, `{"ErrorKey": "edgerule.validation", "Message": "Invalid rule"}`)
+		},
+		"GET /pullzone/9": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{"Id": 9, "Name": "copy"}`)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	report, err := client.PullZone.CreateFromTemplate(context.Background(), "copy", &resources.PullZone{
+		OriginUrl: "https://origin.example.com",
+		EdgeRules: []resources.EdgeRule{
+			{Guid: "a", Description: "invalid", ActionType: resources.EdgeRuleActionTypeRedirect, ActionParameter1: "/relative", Enabled: true, Triggers: []resources.EdgeRuleTrigger{{PatternMatches: []string{"*"}}}},
+			{Guid: "b", Description: "rejected", ActionType: resources.EdgeRuleActionTypeForceSSL, Enabled: true, Triggers: []resources.EdgeRuleTrigger{{PatternMatches: []string{"*"}}}},
+		},
+	}, nil)
+	require.Error(t, err)
+
+	require.NotNil(t, report)
+	assert.Equal(t, 0, report.EdgeRules)
+	require.Len(t, report.Skipped, 2)
+	assert.Contains(t, report.Skipped[0].Reason, "client-side validation")
+	assert.Equal(t, "the API rejected the edge rule", report.Skipped[1].Reason)
+}
@@ -0,0 +1,177 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+// listRecorder records the list changes sent to the API
+type listRecorder struct {
+	mu      sync.Mutex
+	changes []string
+}
+
+func (r *listRecorder) handler(action, field string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]string
+		json.NewDecoder(req.Body).Decode(&body)
+		r.mu.Lock()
+		r.changes = append(r.changes, action+" "+body[field])
+		r.mu.Unlock()
+		if body[field] == "203.0.113.9" {
+			test.RespondJSON(w, http.StatusBadRequest, `{"ErrorKey": "pullzone.blocked_ip", "Message": "Invalid IP"}`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (r *listRecorder) sorted() []string {
+	sort.Strings(r.changes)
+	return r.changes
+}
+
+func setupListServer(t *testing.T) (*bunnynet.Client, *listRecorder, func()) {
+	recorder := &listRecorder{}
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /pullzone/5": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{
+				"Id": 5,
+				"BlockedIps": ["192.0.2.1", "198.51.100.0/24", "10.0.0.*"],
+				"AllowedReferrers": ["Example.com", "old.example.com"],
+				"BlockedReferrers": []
+			}`)
+		},
+		"POST /pullzone/5/addBlockedIp":          recorder.handler("add", "BlockedIp"),
+		"POST /pullzone/5/removeBlockedIp":       recorder.handler("remove", "BlockedIp"),
+		"POST /pullzone/5/addAllowedReferrer":    recorder.handler("add", "Hostname"),
+		"POST /pullzone/5/removeAllowedReferrer": recorder.handler("remove", "Hostname"),
+		"POST /pullzone/5/addBlockedReferrer":    recorder.handler("add", "Hostname"),
+	})
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+	return client, recorder, server.Close
+}
+
+func TestPullZoneService_SetBlockedIPs(t *testing.T) {
+	client, recorder, cleanup := setupListServer(t)
+	defer cleanup()
+
+	desired := []netip.Prefix{
+		netip.MustParsePrefix("192.0.2.1/32"),
+		netip.MustParsePrefix("2001:db8::/32"),
+		netip.MustParsePrefix("203.0.113.9/32"),
+	}
+
+	report, err := client.PullZone.SetBlockedIPs(context.Background(), 5, desired, &resources.SyncOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Empty(t, recorder.changes)
+	assert.Equal(t, 1, report.Unchanged)
+	assert.Equal(t, []resources.ListChange{
+		{Action: resources.SyncActionDelete, Value: "10.0.0.*"},
+		{Action: resources.SyncActionDelete, Value: "198.51.100.0/24"},
+		{Action: resources.SyncActionAdd, Value: "2001:db8::/32"},
+		{Action: resources.SyncActionAdd, Value: "203.0.113.9"},
+	}, report.Changes)
+
+	report, err = client.PullZone.SetBlockedIPs(context.Background(), 5, desired, nil)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "add BlockedIps 203.0.113.9")
+	assert.Len(t, report.Failed(), 1)
+	assert.Equal(t, []string{
+		"add 2001:db8::/32",
+		"add 203.0.113.9",
+		"remove 10.0.0.*",
+		"remove 198.51.100.0/24",
+	}, recorder.sorted())
+}
+
+func TestPullZoneService_SetBlockedIPs_Validation(t *testing.T) {
+	client, recorder, cleanup := setupListServer(t)
+	defer cleanup()
+
+	_, err := client.PullZone.SetBlockedIPs(context.Background(), 5, []netip.Prefix{
+		netip.MustParsePrefix("192.0.2.1/24"),
+		{},
+	}, nil)
+
+	var validationErr *common.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.ErrorContains(t, err, "use 192.0.2.0/24")
+	assert.ErrorContains(t, err, "BlockedIps[1]")
+	assert.Empty(t, recorder.changes)
+}
+
+func TestPullZoneService_SetBlockedIPs_MappedAddress(t *testing.T) {
+	client, recorder, cleanup := setupListServer(t)
+	defer cleanup()
+
+	// IPv4-mapped IPv6 addresses and ranges match live IPv4 entries and are added as IPv4
+	report, err := client.PullZone.SetBlockedIPs(context.Background(), 5, []netip.Prefix{
+		netip.MustParsePrefix("::ffff:192.0.2.1/128"),
+		netip.MustParsePrefix("::ffff:203.0.113.7/128"),
+		netip.MustParsePrefix("::ffff:198.51.100.0/120"),
+		netip.MustParsePrefix("::ffff:10.0.0.0/104"),
+	}, &resources.SyncOptions{NoDelete: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Unchanged)
+	assert.ElementsMatch(t, []resources.ListChange{
+		{Action: resources.SyncActionAdd, Value: "203.0.113.7"},
+		{Action: resources.SyncActionAdd, Value: "10.0.0.0/8"},
+	}, report.Changes)
+	assert.Equal(t, []string{"add 10.0.0.0/8", "add 203.0.113.7"}, recorder.sorted())
+}
+
+func TestPullZoneService_SetReferrers(t *testing.T) {
+	client, recorder, cleanup := setupListServer(t)
+	defer cleanup()
+
+	report, err := client.PullZone.SetAllowedReferrers(context.Background(), 5, []string{"example.com.", "*.Example.com"}, &resources.SyncOptions{NoDelete: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old.example.com"}, report.Kept)
+	assert.Equal(t, []string{"add *.example.com"}, recorder.sorted())
+
+	recorder.changes = nil
+	_, err = client.PullZone.SetBlockedReferrers(context.Background(), 5, []string{"spam.example"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"add spam.example"}, recorder.sorted())
+
+	_, err = client.PullZone.SetBlockedReferrers(context.Background(), 5, []string{"https://bad.example/"}, nil)
+	assert.ErrorContains(t, err, "BlockedReferrers[0]")
+}
+
+func TestParseThreatFeed(t *testing.T) {
+	feed := `; Spamhaus DROP List
+; Last-Modified: Thu, 01 Jan 2026 00:00:00 GMT
+1.10.16.0/20 ; SBL256894
+192.0.2.15
+# duplicate with host bits set
+1.10.16.5/20
+2001:db8::1, comment
+
+`
+	prefixes, err := resources.ParseThreatFeed(strings.NewReader(feed))
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("1.10.16.0/20"),
+		netip.MustParsePrefix("192.0.2.15/32"),
+		netip.MustParsePrefix("2001:db8::1/128"),
+	}, prefixes)
+
+	_, err = resources.ParseThreatFeed(strings.NewReader("192.0.2.1\nnot-an-ip\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
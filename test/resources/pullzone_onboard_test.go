@@ -0,0 +1,173 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+// fakeResolver returns fixed canonical names
+type fakeResolver map[string]string
+
+func (r fakeResolver) LookupCNAME(ctx context.Context, host string) (string, error) {
+	return r[host], nil
+}
+
+// onboardServer records the calls made by the onboarding workflow
+type onboardServer struct {
+	mu               sync.Mutex
+	calls            []string
+	certificateFails int
+	forceSSLStatus   int
+}
+
+func (o *onboardServer) record(call string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.calls = append(o.calls, call)
+}
+
+func setupOnboardServer(t *testing.T, o *onboardServer) (*bunnynet.Client, *httptest.Server) {
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /pullzone/5": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{
+				"Id": 5,
+				"CnameDomain": "site.b-cdn.net",
+				"Hostnames": [
+					{"Value": "site.b-cdn.net", "IsSystemHostname": true},
+					{"Value": "existing.example.com"}
+				]
+			}`)
+		},
+		"POST /pullzone/5/addHostname": func(w http.ResponseWriter, r *http.Request) {
+			var options resources.AddHostnameOptions
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+			o.record("add " + options.Hostname)
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"DELETE /pullzone/5/removeHostname": func(w http.ResponseWriter, r *http.Request) {
+			o.record("remove")
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"GET /pullzone/loadFreeCertificate": func(w http.ResponseWriter, r *http.Request) {
+			o.record("certificate " + r.URL.Query().Get("hostname"))
+			o.mu.Lock()
+			fail := o.certificateFails > 0
+			o.certificateFails--
+			o.mu.Unlock()
+			if fail {
+				test.RespondJSON(w, http.StatusBadRequest, `{"ErrorKey": "pullzone.hostname_not_resolving", "Message": "The hostname does not resolve yet"}`)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		},
+		"POST /pullzone/5/setForceSSL": func(w http.ResponseWriter, r *http.Request) {
+			var options resources.SetForceSSLOptions
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+			assert.True(t, options.ForceSSL)
+			o.record("force-ssl " + options.Hostname)
+			if o.forceSSLStatus != 0 {
+				test.RespondJSON(w, o.forceSSLStatus, `{"ErrorKey": "pullzone.internal", "Message": "Internal error"}`)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+
+	return bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL)), server
+}
+
+func TestPullZoneService_OnboardHostname(t *testing.T) {
+	o := &onboardServer{certificateFails: 2}
+	client, server := setupOnboardServer(t, o)
+	defer server.Close()
+
+	var progress []resources.OnboardStep
+	report, err := client.PullZone.OnboardHostname(context.Background(), 5, "CDN.example.com.", &resources.OnboardOptions{
+		Resolver:    fakeResolver{"cdn.example.com": "site.b-cdn.net."},
+		Certificate: &common.WaitOptions{Interval: time.Millisecond},
+		Progress:    func(result resources.OnboardStepResult) { progress = append(progress, result.Step) },
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"add cdn.example.com",
+		"certificate cdn.example.com",
+		"certificate cdn.example.com",
+		"certificate cdn.example.com",
+		"force-ssl cdn.example.com",
+	}, o.calls)
+	assert.Equal(t, []resources.OnboardStep{
+		resources.OnboardStepVerifyDNS,
+		resources.OnboardStepAddHostname,
+		resources.OnboardStepLoadCertificate,
+		resources.OnboardStepForceSSL,
+	}, progress)
+
+	assert.Equal(t, "site.b-cdn.net", report.CnameDomain)
+	assert.Equal(t, "cdn.example.com -> site.b-cdn.net", report.Steps[0].Detail)
+	assert.Equal(t, 3, report.Steps[2].Attempts)
+	assert.False(t, report.RolledBack)
+}
+
+func TestPullZoneService_OnboardHostname_CNAMEMismatch(t *testing.T) {
+	o := &onboardServer{}
+	client, server := setupOnboardServer(t, o)
+	defer server.Close()
+
+	report, err := client.PullZone.OnboardHostname(context.Background(), 5, "cdn.example.com", &resources.OnboardOptions{
+		Resolver: fakeResolver{"cdn.example.com": "cdn.example.com."},
+	})
+	assert.ErrorIs(t, err, resources.ErrCNAMEMismatch)
+	assert.Empty(t, o.calls)
+	require.Len(t, report.Steps, 1)
+	assert.Error(t, report.Steps[0].Err)
+}
+
+func TestPullZoneService_OnboardHostname_Rollback(t *testing.T) {
+	o := &onboardServer{forceSSLStatus: http.StatusInternalServerError}
+	client, server := setupOnboardServer(t, o)
+	defer server.Close()
+
+	report, err := client.PullZone.OnboardHostname(context.Background(), 5, "cdn.example.com", &resources.OnboardOptions{
+		SkipDNSCheck: true,
+	})
+	require.Error(t, err)
+	assert.True(t, report.RolledBack)
+	assert.True(t, report.Steps[0].Skipped)
+	assert.Equal(t, []string{
+		"add cdn.example.com",
+		"certificate cdn.example.com",
+		"force-ssl cdn.example.com",
+		"remove",
+	}, o.calls)
+	assert.Equal(t, resources.OnboardStepRollback, report.Steps[len(report.Steps)-1].Step)
+}
+
+func TestPullZoneService_OnboardHostname_ExistingHostname(t *testing.T) {
+	o := &onboardServer{certificateFails: 100}
+	client, server := setupOnboardServer(t, o)
+	defer server.Close()
+
+	// A hostname that was already on the zone is not removed when the certificate cannot be issued
+	report, err := client.PullZone.OnboardHostname(context.Background(), 5, "existing.example.com", &resources.OnboardOptions{
+		SkipDNSCheck: true,
+		Certificate:  &common.WaitOptions{Interval: time.Millisecond, Timeout: 20 * time.Millisecond},
+	})
+	assert.ErrorIs(t, err, common.ErrWaitTimeout)
+	assert.ErrorContains(t, err, "does not resolve yet")
+	assert.False(t, report.RolledBack)
+	assert.True(t, report.Steps[1].Skipped)
+	assert.NotContains(t, o.calls, "remove")
+}
@@ -0,0 +1,172 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+func setupZonePurgeServer(t *testing.T) (*bunnynet.Client, *purgeRecorder, func()) {
+	recorder := &purgeRecorder{}
+	var mu sync.Mutex
+	zonePurges := 0
+
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /pullzone/5": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{
+				"Id": 5,
+				"Name": "site",
+				"Hostnames": [
+					{"Value": "site.b-cdn.net", "IsSystemHostname": true},
+					{"Value": "example.com"},
+					{"Value": "www.example.com"}
+				]
+			}`)
+		},
+		"GET /pullzone/4": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{"Id": 4, "Name": "other", "Hostnames": [{"Value": "other.b-cdn.net", "IsSystemHostname": true}]}`)
+		},
+		"GET /pullzone": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{
+				"Items": [
+					{"Id": 4, "Name": "other", "Hostnames": [{"Value": "other.b-cdn.net", "IsSystemHostname": true}]},
+					{"Id": 5, "Name": "site", "Hostnames": [{"Value": "site.b-cdn.net", "IsSystemHostname": true}, {"Value": "example.com"}]}
+				],
+				"CurrentPage": 1,
+				"TotalItems": 2,
+				"HasMoreItems": false
+			}`)
+		},
+		"POST /pullzone/5/purgeCache": func(w http.ResponseWriter, r *http.Request) {
+			var options resources.PurgeCacheOptions
+			json.NewDecoder(r.Body).Decode(&options)
+			if options.CacheTag == "" {
+				mu.Lock()
+				zonePurges++
+				mu.Unlock()
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			recorder.mu.Lock()
+			recorder.tags = append(recorder.tags, options.CacheTag)
+			recorder.mu.Unlock()
+			if options.CacheTag == "broken" {
+				test.RespondJSON(w, http.StatusBadRequest, `{"ErrorKey": "purge.invalid_tag", "Message": "Invalid tag"}`)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"POST /purge": func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.URL.Query().Get("async"))
+			recorder.mu.Lock()
+			recorder.urls = append(recorder.urls, r.URL.Query().Get("url"))
+			recorder.mu.Unlock()
+			if strings.HasSuffix(r.URL.Query().Get("url"), "/broken.html") {
+				test.RespondJSON(w, http.StatusInternalServerError, `{"ErrorKey": "purge.failed", "Message": "Purge failed"}`)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		},
+	})
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+	return client, recorder, server.Close
+}
+
+func TestPullZoneService_Purge(t *testing.T) {
+	client, recorder, cleanup := setupZonePurgeServer(t)
+	defer cleanup()
+
+	report, err := client.PullZone.Purge(context.Background(), 5, &resources.ZonePurgeOptions{
+		Tags:  []string{"blog", "news", "blog", "broken"},
+		Paths: []string{"/images/*", "about.html"},
+		Wait:  true,
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "tag broken")
+
+	assert.ElementsMatch(t, []string{"blog", "news", "broken"}, recorder.tags)
+	assert.ElementsMatch(t, []string{
+		"https://example.com/images/*",
+		"https://example.com/about.html",
+		"https://www.example.com/images/*",
+		"https://www.example.com/about.html",
+	}, recorder.urls)
+
+	require.Len(t, report.Results, 7)
+	failed := report.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, resources.ZonePurgeKindTag, failed[0].Kind)
+	assert.Equal(t, "broken", failed[0].Target)
+}
+
+func TestPullZoneService_Purge_All(t *testing.T) {
+	client, recorder, cleanup := setupZonePurgeServer(t)
+	defer cleanup()
+
+	report, err := client.PullZone.Purge(context.Background(), 5, &resources.ZonePurgeOptions{All: true, Tags: []string{"ignored"}})
+	require.NoError(t, err)
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, resources.ZonePurgeKindZone, report.Results[0].Kind)
+	assert.Empty(t, recorder.tags)
+
+	_, err = client.PullZone.Purge(context.Background(), 5, &resources.ZonePurgeOptions{})
+	assert.Error(t, err)
+}
+
+func TestPullZoneService_Purge_SystemHostnamesOnly(t *testing.T) {
+	client, recorder, cleanup := setupZonePurgeServer(t)
+	defer cleanup()
+
+	// Paths on a zone with only a system hostname fail instead of purging nothing
+	_, err := client.PullZone.Purge(context.Background(), 4, &resources.ZonePurgeOptions{
+		Tags:  []string{"blog"},
+		Paths: []string{"/about.html"},
+		Wait:  true,
+	})
+	assert.ErrorContains(t, err, "no custom hostnames")
+	assert.Empty(t, recorder.tags)
+	assert.Empty(t, recorder.urls)
+
+	report, err := client.PullZone.Purge(context.Background(), 4, &resources.ZonePurgeOptions{
+		Paths:                  []string{"/about.html", "/broken.html"},
+		IncludeSystemHostnames: true,
+		Wait:                   true,
+	})
+	assert.ErrorContains(t, err, "https://other.b-cdn.net/broken.html")
+	assert.ElementsMatch(t, []string{"https://other.b-cdn.net/about.html", "https://other.b-cdn.net/broken.html"}, recorder.urls)
+
+	failed := report.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, resources.ZonePurgeKindURL, failed[0].Kind)
+	assert.Equal(t, "https://other.b-cdn.net/broken.html", failed[0].Target)
+}
+
+func TestPullZoneService_Resolve(t *testing.T) {
+	client, _, cleanup := setupZonePurgeServer(t)
+	defer cleanup()
+
+	for _, ref := range []string{"5", "SITE", "example.com", "site.b-cdn.net"} {
+		zone, err := client.PullZone.Resolve(context.Background(), ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, int64(5), zone.Id, ref)
+	}
+
+	_, err := client.PullZone.Resolve(context.Background(), "missing.example.com")
+	assert.True(t, errors.Is(err, common.ErrNotFound))
+
+	report, err := client.PullZone.PurgeByRef(context.Background(), "example.com", &resources.ZonePurgeOptions{Tags: []string{"blog"}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), report.PullZoneId)
+}
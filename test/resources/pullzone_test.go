@@ -2,12 +2,17 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/common"
 	"github.com/venom90/bunnynet-go/resources"
 	"github.com/venom90/bunnynet-go/test"
 )
@@ -147,7 +152,7 @@ func TestPullZoneService_Update_Success(t *testing.T) {
 	assert.Equal(t, []string{"badsite.com"}, pullZone.BlockedReferrers)
 	assert.True(t, pullZone.EnableGeoZoneUS)
 	assert.False(t, pullZone.EnableGeoZoneEU)
-	assert.Equal(t, 0, pullZone.Type)
+	assert.Equal(t, resources.PullZoneTypePremium, pullZone.Type)
 }
 
 func TestPullZoneService_Delete_Error(t *testing.T) {
@@ -337,23 +342,22 @@ func TestPullZoneService_AddOrUpdateEdgeRule_Error(t *testing.T) {
 	// Create a mock server that returns an error
 	server := test.MockServer(t, http.StatusBadRequest, `{
 		"ErrorKey": "edgerule.invalid",
-		"Field": "ActionType",
-		"Message": "The provided action type is invalid"
+		"Field": "ActionParameter1",
+		"Message": "The provided origin URL is invalid"
 	}`, nil)
 	defer server.Close()
 
 	// Create a client that uses the mock server
 	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
 
-	// Call the AddOrUpdateEdgeRule method with an invalid action type
+	// Call the AddOrUpdateEdgeRule method with options that pass client-side validation
 	options := resources.AddOrUpdateEdgeRuleOptions{
-		ActionType: 999, // Invalid action type
+		ActionType:       resources.EdgeRuleActionTypeOriginUrl,
+		ActionParameter1: "https://unreachable.example.com",
 		Triggers: []resources.EdgeRuleTrigger{
 			{
-				Type:                0,
-				PatternMatches:      []string{"example.com/*"},
-				PatternMatchingType: 0,
-				TriggerMatchingType: 0,
+				Type:           resources.EdgeRuleTriggerTypeUrl,
+				PatternMatches: []string{"example.com/*"},
 			},
 		},
 		Enabled: true,
@@ -363,6 +367,37 @@ func TestPullZoneService_AddOrUpdateEdgeRule_Error(t *testing.T) {
 	assert.Contains(t, err.Error(), "edgerule.invalid")
 }
 
+func TestPullZoneService_AddOrUpdateEdgeRule_ValidationError(t *testing.T) {
+	// Create a mock server that fails the test if it is called
+	server := test.MockServer(t, http.StatusCreated, ``, func(r *http.Request) {
+		t.Error("Invalid edge rules should not be sent to the API")
+	})
+	defer server.Close()
+
+	// Create a client that uses the mock server
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	// Call the AddOrUpdateEdgeRule method with an invalid action type and triggers
+	options := resources.AddOrUpdateEdgeRuleOptions{
+		ActionType: -1, // Invalid action type
+		Triggers: []resources.EdgeRuleTrigger{
+			{
+				Type:           resources.EdgeRuleTriggerTypeCountryCode,
+				PatternMatches: []string{"Germany"},
+			},
+		},
+		Enabled: true,
+	}
+	err := client.PullZone.AddOrUpdateEdgeRule(context.Background(), 12345, options)
+	require.Error(t, err, "AddOrUpdateEdgeRule should return an error")
+
+	var validationErr *common.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Len(t, validationErr.Errors, 2)
+	assert.Equal(t, "ActionType", validationErr.Errors[0].Field)
+	assert.Equal(t, "Triggers[0].PatternMatches", validationErr.Errors[1].Field)
+}
+
 func TestPullZoneService_AddOrUpdateEdgeRule_Success(t *testing.T) {
 	// Create a mock server
 	server := test.MockServer(t, http.StatusCreated, ``, func(r *http.Request) {
@@ -652,16 +687,16 @@ func TestPullZoneService_Get_Success(t *testing.T) {
 	assert.Equal(t, []string{"badsite.com"}, pullZone.BlockedReferrers)
 	assert.True(t, pullZone.EnableGeoZoneUS)
 	assert.True(t, pullZone.EnableGeoZoneEU)
-	assert.Equal(t, 0, pullZone.Type)
+	assert.Equal(t, resources.PullZoneTypePremium, pullZone.Type)
 
 	// Verify edge rules
 	assert.Len(t, pullZone.EdgeRules, 1)
 	assert.Equal(t, "abcd1234", pullZone.EdgeRules[0].Guid)
-	assert.Equal(t, 0, pullZone.EdgeRules[0].ActionType)
+	assert.Equal(t, resources.EdgeRuleActionTypeForceSSL, pullZone.EdgeRules[0].ActionType)
 	assert.Equal(t, "Force SSL for example.com", pullZone.EdgeRules[0].Description)
 	assert.True(t, pullZone.EdgeRules[0].Enabled)
 	assert.Len(t, pullZone.EdgeRules[0].Triggers, 1)
-	assert.Equal(t, 0, pullZone.EdgeRules[0].Triggers[0].Type)
+	assert.Equal(t, resources.EdgeRuleTriggerTypeUrl, pullZone.EdgeRules[0].Triggers[0].Type)
 	assert.Equal(t, []string{"example.com/*"}, pullZone.EdgeRules[0].Triggers[0].PatternMatches)
 }
 
@@ -734,5 +769,152 @@ func TestPullZoneService_Add_Success(t *testing.T) {
 	assert.Equal(t, []string{"badsite.com"}, pullZone.BlockedReferrers)
 	assert.True(t, pullZone.EnableGeoZoneUS)
 	assert.True(t, pullZone.EnableGeoZoneEU)
-	assert.Equal(t, 0, pullZone.Type)
+	assert.Equal(t, resources.PullZoneTypePremium, pullZone.Type)
+}
+
+func TestPullZoneService_UpdatePartial_Success(t *testing.T) {
+	// Create a mock server
+	server := test.MockServer(t, http.StatusOK, `{
+		"Id": 12345,
+		"Name": "test-zone-1",
+		"EnableLogging": false,
+		"CacheErrorResponses": true
+	}`, func(r *http.Request) {
+		test.AssertRequestMethod(t, r, http.MethodPost)
+		test.AssertRequestPath(t, r, "/pullzone/12345")
+		test.AssertRequestHasHeader(t, r, "AccessKey", "test-api-key")
+
+		// Only the set fields should be sent, including zero values
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"EnableLogging": false, "CacheErrorResponses": true, "BlockedIps": []}`, string(body))
+	})
+	defer server.Close()
+
+	// Create a client that uses the mock server
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	// Call the UpdatePartial method
+	pullZone, err := client.PullZone.UpdatePartial(context.Background(), 12345, resources.UpdatePullZoneOptions{
+		EnableLogging:       common.Ptr(false),
+		CacheErrorResponses: common.Ptr(true),
+		BlockedIps:          common.Ptr([]string{}),
+	})
+	assert.NoError(t, err, "UpdatePartial should not return an error")
+	assert.NotNil(t, pullZone, "Pull zone should not be nil")
+	assert.False(t, pullZone.EnableLogging)
+	assert.True(t, pullZone.CacheErrorResponses)
+}
+
+func TestPullZoneService_UpdatePartial_Error(t *testing.T) {
+	// Create a mock server that returns an error
+	server := test.MockServer(t, http.StatusNotFound, `{
+		"ErrorKey": "pullzone.not_found",
+		"Field": "PullZoneId",
+		"Message": "The requested Pull Zone was not found"
+	}`, nil)
+	defer server.Close()
+
+	// Create a client that uses the mock server
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	// Call the UpdatePartial method with an invalid ID
+	pullZone, err := client.PullZone.UpdatePartial(context.Background(), 99999, resources.UpdatePullZoneOptions{
+		Enabled: common.Ptr(false),
+	})
+	assert.Error(t, err, "UpdatePartial should return an error")
+	assert.Nil(t, pullZone, "Pull zone should be nil")
+	assert.Contains(t, err.Error(), "pullzone.not_found")
+}
+
+func TestPullZoneService_Patch_Success(t *testing.T) {
+	// Create a mock server
+	server := test.MockServer(t, http.StatusOK, `{
+		"Id": 12345,
+		"Name": "test-zone-1",
+		"Enabled": false,
+		"BlockedIps": ["192.0.2.1"]
+	}`, func(r *http.Request) {
+		test.AssertRequestMethod(t, r, http.MethodPost)
+		test.AssertRequestPath(t, r, "/pullzone/12345")
+
+		// Only the changed fields should be sent
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"Enabled": false, "BlockedIps": ["192.0.2.1"]}`, string(body))
+	})
+	defer server.Close()
+
+	// Create a client that uses the mock server
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	original := &resources.PullZone{
+		Id:               12345,
+		Name:             "test-zone-1",
+		Enabled:          true,
+		AllowedReferrers: []string{},
+		EdgeRules:        []resources.EdgeRule{{Guid: "rule-1"}},
+	}
+	modified := *original
+	modified.Enabled = false
+	modified.BlockedIps = []string{"192.0.2.1"}
+	modified.AllowedReferrers = nil
+	modified.EdgeRules = nil
+
+	// Call the Patch method
+	pullZone, err := client.PullZone.Patch(context.Background(), 12345, original, &modified)
+	assert.NoError(t, err, "Patch should not return an error")
+	assert.NotNil(t, pullZone, "Pull zone should not be nil")
+	assert.False(t, pullZone.Enabled)
+}
+
+func TestPullZoneService_Patch_NoChanges(t *testing.T) {
+	// Create a mock server that fails the test if it is called
+	server := test.MockServer(t, http.StatusOK, `{}`, func(r *http.Request) {
+		t.Errorf("Patch should not call the API when nothing changed")
+	})
+	defer server.Close()
+
+	// Create a client that uses the mock server
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	original := &resources.PullZone{Id: 12345, OriginUrl: "https://example.com"}
+	modified := *original
+	modified.MonthlyBandwidthUsed = 1024
+
+	pullZone, err := client.PullZone.Patch(context.Background(), 12345, original, &modified)
+	assert.NoError(t, err, "Patch should not return an error")
+	assert.Equal(t, &modified, pullZone)
+}
+
+func TestDiffPullZones(t *testing.T) {
+	// Every update option must map onto a PullZone field of the same type
+	optionsType := reflect.TypeOf(resources.UpdatePullZoneOptions{})
+	pullZoneType := reflect.TypeOf(resources.PullZone{})
+	for i := 0; i < optionsType.NumField(); i++ {
+		field := optionsType.Field(i)
+		pullZoneField, ok := pullZoneType.FieldByName(field.Name)
+		if assert.True(t, ok, "PullZone should have field %s", field.Name) {
+			assert.Equal(t, reflect.PointerTo(pullZoneField.Type), field.Type, "Field %s should have a matching type", field.Name)
+		}
+	}
+
+	original := &resources.PullZone{OriginUrl: "https://a.example.com", CacheControlMaxAgeOverride: 60}
+	modified := &resources.PullZone{OriginUrl: "https://b.example.com", CacheControlMaxAgeOverride: 60}
+
+	options := resources.DiffPullZones(original, modified)
+	assert.False(t, options.IsEmpty())
+	assert.Equal(t, "https://b.example.com", *options.OriginUrl)
+	assert.Nil(t, options.CacheControlMaxAgeOverride, "Unchanged fields should not be set")
+	assert.True(t, resources.DiffPullZones(original, original).IsEmpty())
+}
+
+func TestDiffPullZones_ClearList(t *testing.T) {
+	original := &resources.PullZone{BlockedIps: []string{"192.0.2.1"}}
+	modified := &resources.PullZone{}
+
+	options := resources.DiffPullZones(original, modified)
+	data, err := json.Marshal(options)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"BlockedIps": []}`, string(data), "Clearing a list should send an empty list, not null")
 }
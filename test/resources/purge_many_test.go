@@ -0,0 +1,138 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+func TestNormalizePurgeURL(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"https://Example.COM", "https://example.com/"},
+		{"HTTPS://example.com:443/a.jpg#top", "https://example.com/a.jpg"},
+		{"http://example.com:80/a.jpg", "http://example.com/a.jpg"},
+		{"https://example.com:8443/a.jpg?v=2", "https://example.com:8443/a.jpg?v=2"},
+		{"  https://example.com/images/*  ", "https://example.com/images/*"},
+		{"https://example.com/my images/*", "https://example.com/my%20images/*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := resources.NormalizePurgeURL(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, input := range []string{"ftp://example.com/a", "/relative/path", "https://"} {
+		_, err := resources.NormalizePurgeURL(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestPurgeService_PurgeMany(t *testing.T) {
+	var mu sync.Mutex
+	var purged []string
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"POST /purge": func(w http.ResponseWriter, r *http.Request) {
+			u := r.URL.Query().Get("url")
+			assert.Equal(t, "true", r.URL.Query().Get("async"))
+
+			mu.Lock()
+			purged = append(purged, u)
+			mu.Unlock()
+
+			if strings.Contains(u, "fail") {
+				test.RespondJSON(w, http.StatusInternalServerError, `{"ErrorKey": "purge.failed", "Message": "Purge failed"}`)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	urls := []string{
+		"https://example.com/css/site.css",
+		"https://EXAMPLE.com/css/site.css",
+		"https://example.com/img/a.png",
+		"https://example.com/img/b.png",
+		"https://example.com/img/c.png?v=1",
+		"https://example.com/js/*",
+		"https://example.com/js/app.js",
+		"https://example.com/js/vendor/lib.js",
+		"https://example.com/fail.html",
+		"not a url",
+	}
+
+	report, err := client.Purge.PurgeMany(context.Background(), urls, &resources.PurgeManyOptions{
+		Async:             true,
+		Concurrency:       2,
+		WildcardThreshold: 3,
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "fail.html")
+	assert.ErrorContains(t, err, "not a url")
+
+	assert.Equal(t, []string{
+		"https://example.com/css/site.css",
+		"https://example.com/fail.html",
+		"https://example.com/img/*",
+		"https://example.com/js/*",
+	}, report.Purged)
+	assert.ElementsMatch(t, report.Purged, purged)
+
+	require.Len(t, report.Results, len(urls))
+	assert.Equal(t, "https://example.com/css/site.css", report.Results[1].PurgedAs)
+	assert.Equal(t, "https://example.com/img/*", report.Results[4].PurgedAs)
+	assert.Equal(t, "https://example.com/js/*", report.Results[7].PurgedAs)
+	assert.NoError(t, report.Results[0].Err)
+
+	failed := report.Failed()
+	require.Len(t, failed, 2)
+	assert.Equal(t, "https://example.com/fail.html", failed[0].URL)
+	assert.Equal(t, "not a url", failed[1].URL)
+}
+
+func TestPurgeService_PurgeMany_RateLimit(t *testing.T) {
+	var mu sync.Mutex
+	var times []time.Time
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"POST /purge": func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			times = append(times, time.Now())
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	start := time.Now()
+	report, err := client.Purge.PurgeMany(context.Background(), []string{
+		"https://example.com/1",
+		"https://example.com/2",
+		"https://example.com/3",
+		"https://example.com/4",
+	}, &resources.PurgeManyOptions{RequestsPerSecond: 50})
+	require.NoError(t, err)
+	assert.Len(t, report.Purged, 4)
+	assert.Len(t, times, 4)
+
+	// Four requests at 50 per second take at least three intervals of 20ms
+	assert.GreaterOrEqual(t, time.Since(start), 60*time.Millisecond)
+}
@@ -0,0 +1,130 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+// purgeRecorder records the purge requests received by the mock API
+type purgeRecorder struct {
+	mu   sync.Mutex
+	urls []string
+	tags []string
+}
+
+func setupPurgeQueueServer(t *testing.T, recorder *purgeRecorder) (*bunnynet.Client, func()) {
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"POST /purge": func(w http.ResponseWriter, r *http.Request) {
+			u := r.URL.Query().Get("url")
+			recorder.mu.Lock()
+			recorder.urls = append(recorder.urls, u)
+			recorder.mu.Unlock()
+			if u == "https://example.com/broken" {
+				test.RespondJSON(w, http.StatusInternalServerError, `{"ErrorKey": "purge.failed", "Message": "Purge failed"}`)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		},
+		"POST /pullzone/5/purgeCache": func(w http.ResponseWriter, r *http.Request) {
+			var options resources.PurgeCacheOptions
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+			recorder.mu.Lock()
+			recorder.tags = append(recorder.tags, options.CacheTag)
+			recorder.mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+
+	return bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL)), server.Close
+}
+
+func TestPurgeQueue_Coalesce(t *testing.T) {
+	recorder := &purgeRecorder{}
+	client, cleanup := setupPurgeQueueServer(t, recorder)
+	defer cleanup()
+
+	queue := resources.NewPurgeQueue(client.Purge, client.PullZone, &resources.PurgeQueueOptions{Window: 20 * time.Millisecond})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, queue.PurgeURL("https://example.com/index.html"))
+			assert.NoError(t, queue.PurgeURL("https://EXAMPLE.com/index.html#top"))
+			assert.NoError(t, queue.PurgeTag(5, "articles"))
+		}()
+	}
+	wg.Wait()
+
+	stats := queue.Stats()
+	assert.Equal(t, int64(30), stats.Queued)
+	assert.Equal(t, int64(28), stats.Coalesced)
+	assert.Equal(t, 2, stats.Pending)
+
+	assert.Eventually(t, func() bool { return queue.Stats().Purged == 2 }, time.Second, 5*time.Millisecond)
+
+	recorder.mu.Lock()
+	assert.Equal(t, []string{"https://example.com/index.html"}, recorder.urls)
+	assert.Equal(t, []string{"articles"}, recorder.tags)
+	recorder.mu.Unlock()
+
+	// An item added again after its window is purged again
+	require.NoError(t, queue.PurgeURL("https://example.com/index.html"))
+	require.NoError(t, queue.Close(context.Background()))
+
+	recorder.mu.Lock()
+	assert.Len(t, recorder.urls, 2)
+	recorder.mu.Unlock()
+}
+
+func TestPurgeQueue_FlushAndClose(t *testing.T) {
+	recorder := &purgeRecorder{}
+	client, cleanup := setupPurgeQueueServer(t, recorder)
+	defer cleanup()
+
+	var failedItems []resources.PurgeItem
+	var mu sync.Mutex
+	queue := resources.NewPurgeQueue(client.Purge, nil, &resources.PurgeQueueOptions{
+		Window: time.Hour,
+		OnError: func(item resources.PurgeItem, err error) {
+			mu.Lock()
+			failedItems = append(failedItems, item)
+			mu.Unlock()
+		},
+	})
+
+	assert.Error(t, queue.PurgeURL("not a url"))
+	assert.Error(t, queue.PurgeTag(5, "articles"))
+
+	require.NoError(t, queue.PurgeURL("https://example.com/a"))
+	require.NoError(t, queue.PurgeURL("https://example.com/broken"))
+
+	err := queue.Flush(context.Background())
+	assert.ErrorContains(t, err, "https://example.com/broken")
+	require.Len(t, failedItems, 1)
+	assert.Equal(t, "https://example.com/broken", failedItems[0].URL)
+
+	// Close drains the items still waiting for the window
+	require.NoError(t, queue.PurgeURL("https://example.com/b"))
+	require.NoError(t, queue.Close(context.Background()))
+
+	stats := queue.Stats()
+	assert.Equal(t, int64(2), stats.Purged)
+	assert.Equal(t, int64(1), stats.Failed)
+	assert.Equal(t, 0, stats.Pending)
+	assert.Equal(t, 0, stats.InFlight)
+	assert.ElementsMatch(t, []string{"https://example.com/a", "https://example.com/broken", "https://example.com/b"}, recorder.urls)
+
+	assert.ErrorIs(t, queue.PurgeURL("https://example.com/c"), resources.ErrPurgeQueueClosed)
+}
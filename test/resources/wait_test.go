@@ -0,0 +1,84 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go"
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/test"
+)
+
+func TestDNSZoneService_WaitForNameservers(t *testing.T) {
+	polls := 0
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /dnszone/10": func(w http.ResponseWriter, r *http.Request) {
+			polls++
+			test.RespondJSON(w, http.StatusOK, fmt.Sprintf(`{"Id": 10, "Domain": "example.com", "NameserversDetected": %t}`, polls == 3))
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	zone, err := client.DNSZone.WaitForNameservers(context.Background(), 10, time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, zone.NameserversDetected)
+	assert.Equal(t, 3, polls)
+}
+
+func TestDNSZoneService_WaitForNameservers_Timeout(t *testing.T) {
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /dnszone/10": func(w http.ResponseWriter, r *http.Request) {
+			test.RespondJSON(w, http.StatusOK, `{"Id": 10, "Domain": "example.com", "NameserversDetected": false}`)
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	zone, err := client.DNSZone.WaitForNameservers(ctx, 10, time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotNil(t, zone)
+	assert.Equal(t, "example.com", zone.Domain)
+}
+
+func TestPullZoneService_WaitForCertificate(t *testing.T) {
+	polls := 0
+	server := test.MockRouter(t, map[string]http.HandlerFunc{
+		"GET /pullzone/5": func(w http.ResponseWriter, r *http.Request) {
+			polls++
+			test.RespondJSON(w, http.StatusOK, fmt.Sprintf(`{
+				"Id": 5,
+				"Hostnames": [
+					{"Id": 1, "Value": "zone.b-cdn.net", "IsSystemHostname": true, "HasCertificate": true},
+					{"Id": 2, "Value": "cdn.example.com", "HasCertificate": %t}
+				]
+			}`, polls >= 2))
+		},
+	})
+	defer server.Close()
+
+	client := bunnynet.NewClient("test-api-key", bunnynet.WithBaseURL(server.URL))
+
+	var progress []common.WaitProgress
+	hostname, err := client.PullZone.WaitForCertificate(context.Background(), 5, "CDN.example.com", &common.WaitOptions{
+		Interval: time.Millisecond,
+		Progress: func(p common.WaitProgress) { progress = append(progress, p) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), hostname.Id)
+	assert.True(t, hostname.HasCertificate)
+	assert.Len(t, progress, 1)
+
+	_, err = client.PullZone.WaitForCertificate(context.Background(), 5, "missing.example.com", &common.WaitOptions{Interval: time.Millisecond})
+	assert.ErrorIs(t, err, common.ErrNotFound)
+}
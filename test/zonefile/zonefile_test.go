@@ -0,0 +1,169 @@
+package zonefile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/venom90/bunnynet-go/resources"
+	"github.com/venom90/bunnynet-go/zonefile"
+)
+
+const testZoneFile = `
+$ORIGIN example.com.
+$TTL 1h
+@       IN  SOA ns1.example.com. hostmaster.example.com. (
+                2024010101 ; serial
+                3600       ; refresh
+                600        ; retry
+                604800     ; expire
+                300 )      ; minimum
+@           IN  A       192.0.2.1
+            IN  AAAA    2001:db8::1
+www     300 IN  CNAME   @
+mail        IN  MX      10 mx1
+@           IN  MX      20 mx2.example.net.
+_sip._tcp   IN  SRV     10 60 5060 sip.example.com.
+@           IN  CAA     0 issue "letsencrypt.org"
+@       IN 600  TXT     ( "v=spf1 include:_spf.example.net"
+                          " ~all" ) ; joined
+quote       IN  TXT     "say \"hi\"; now"
+$ORIGIN sub.example.com.
+host        2d  A       198.51.100.7
+`
+
+func TestParse_Success(t *testing.T) {
+	records, err := zonefile.Parse(strings.NewReader(testZoneFile), "")
+	require.NoError(t, err, "Parse should not return an error")
+
+	expected := []resources.AddDNSRecordOptions{
+		{Type: resources.DNSRecordTypeA, Name: "", Value: "192.0.2.1", Ttl: 3600},
+		{Type: resources.DNSRecordTypeAAAA, Name: "", Value: "2001:db8::1", Ttl: 3600},
+		{Type: resources.DNSRecordTypeCNAME, Name: "www", Value: "example.com", Ttl: 300},
+		{Type: resources.DNSRecordTypeMX, Name: "mail", Value: "mx1.example.com", Priority: 10, Ttl: 3600},
+		{Type: resources.DNSRecordTypeMX, Name: "", Value: "mx2.example.net", Priority: 20, Ttl: 3600},
+		{Type: resources.DNSRecordTypeSRV, Name: "_sip._tcp", Value: "sip.example.com", Priority: 10, Weight: 60, Port: 5060, Ttl: 3600},
+		{Type: resources.DNSRecordTypeCAA, Name: "", Value: "letsencrypt.org", Flags: 0, Tag: "issue", Ttl: 3600},
+		{Type: resources.DNSRecordTypeTXT, Name: "", Value: "v=spf1 include:_spf.example.net ~all", Ttl: 600},
+		{Type: resources.DNSRecordTypeTXT, Name: "quote", Value: `say "hi"; now`, Ttl: 3600},
+		{Type: resources.DNSRecordTypeA, Name: "host.sub", Value: "198.51.100.7", Ttl: 172800},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestParse_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		zone   string
+		origin string
+		error  string
+	}{
+		{"no origin", "www IN A 192.0.2.1\n", "", "line 1: no origin"},
+		{"outside zone", "other.org. IN A 192.0.2.1\n", "example.com", "outside of zone"},
+		{"bad address", "www IN A 2001:db8::1\n", "example.com", "line 1: A record: invalid address"},
+		{"unsupported type", "\nwww IN SSHFP 1 1 abcd\n", "example.com", "line 2: unsupported record type SSHFP"},
+		{"unbalanced", "www IN TXT ( \"a\"\n", "example.com", "unbalanced parenthesis"},
+		{"unterminated", "www IN TXT \"a\n", "example.com", "unterminated quoted string"},
+		{"include", "$INCLUDE other.zone\n", "example.com", "unsupported directive $INCLUDE"},
+		{"srv fields", "_x._tcp IN SRV 1 2 host.\n", "example.com", "expected 4 fields"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := zonefile.Parse(strings.NewReader(tt.zone), tt.origin)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.error)
+
+			var parseErr *zonefile.ParseError
+			assert.ErrorAs(t, err, &parseErr)
+		})
+	}
+}
+
+func TestRender_Success(t *testing.T) {
+	zone := &resources.DNSZone{
+		Domain: "example.com",
+		Records: []resources.DNSRecord{
+			{Type: resources.DNSRecordTypeMX, Name: "", Value: "mx.example.com", Priority: 10, Ttl: 300},
+			{Type: resources.DNSRecordTypeA, Name: "www", Value: "192.0.2.1", Ttl: 300},
+			{Type: resources.DNSRecordTypeA, Name: "", Value: "192.0.2.1", Ttl: 300},
+			{Type: resources.DNSRecordTypeCAA, Name: "", Value: "letsencrypt.org", Tag: "issue", Ttl: 300},
+			{Type: resources.DNSRecordTypePullZone, Name: "cdn", LinkName: "assets", Ttl: 300},
+			{Type: resources.DNSRecordTypeRedirect, Name: "old", Value: "https://example.com", Ttl: 300},
+			{Type: resources.DNSRecordTypeTXT, Name: "off", Value: "disabled", Disabled: true},
+		},
+	}
+
+	data, skipped := zonefile.Render(zone)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 6)
+	assert.Equal(t, "$ORIGIN example.com.", lines[0])
+	assert.Equal(t, []string{"@", "300", "IN", "A", "192.0.2.1"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"@", "300", "IN", "CAA", "0", "issue", `"letsencrypt.org"`}, strings.Fields(lines[2]))
+	assert.Equal(t, []string{"@", "300", "IN", "MX", "10", "mx.example.com."}, strings.Fields(lines[3]))
+	assert.Equal(t, []string{";", "off", "IN", "TXT", `"disabled"`}, strings.Fields(lines[4]))
+	assert.Equal(t, []string{"www", "300", "IN", "A", "192.0.2.1"}, strings.Fields(lines[5]))
+
+	require.Len(t, skipped, 2)
+	assert.Equal(t, "cdn", skipped[0].Name)
+	assert.Equal(t, "old", skipped[1].Name)
+}
+
+func TestRender_RoundTrip(t *testing.T) {
+	longText := strings.Repeat("k", 300) + " \"quoted\" \\ é"
+	zone := &resources.DNSZone{
+		Domain: "example.com.",
+		Records: []resources.DNSRecord{
+			{Type: resources.DNSRecordTypeA, Name: "", Value: "192.0.2.1", Ttl: 300},
+			{Type: resources.DNSRecordTypeCNAME, Name: "www", Value: "example.com", Ttl: 60},
+			{Type: resources.DNSRecordTypeSRV, Name: "_sip._tcp", Value: "sip.example.com", Priority: 10, Weight: 5, Port: 5060, Ttl: 300},
+			{Type: resources.DNSRecordTypeTXT, Name: "long", Value: longText, Ttl: 300},
+			{Type: resources.DNSRecordTypeNS, Name: "delegated", Value: "ns1.other.net"},
+		},
+	}
+
+	data, skipped := zonefile.Render(zone)
+	assert.Empty(t, skipped)
+
+	records, err := zonefile.Parse(bytes.NewReader(data), "")
+	require.NoError(t, err, "Rendered zone files should parse")
+	require.Len(t, records, len(zone.Records))
+
+	byName := make(map[string]resources.AddDNSRecordOptions)
+	for _, record := range records {
+		byName[record.Name] = record
+	}
+	for _, record := range zone.Records {
+		parsed := byName[record.Name]
+		assert.Equal(t, record.Type, parsed.Type, record.Name)
+		assert.Equal(t, record.Value, parsed.Value, record.Name)
+		assert.Equal(t, record.Ttl, parsed.Ttl, record.Name)
+		assert.Equal(t, record.Priority, parsed.Priority, record.Name)
+		assert.Equal(t, record.Weight, parsed.Weight, record.Name)
+		assert.Equal(t, record.Port, parsed.Port, record.Name)
+	}
+}
+
+func TestRender_RoundTrip_ApexTarget(t *testing.T) {
+	zone := &resources.DNSZone{
+		Domain: "example.com",
+		Records: []resources.DNSRecord{
+			{Type: resources.DNSRecordTypeCNAME, Name: "blog", Value: "@", Ttl: 300},
+		},
+	}
+
+	data, skipped := zonefile.Render(zone)
+	assert.Empty(t, skipped)
+	assert.Contains(t, strings.Fields(string(data)), "@", "An apex target should be written as @")
+	assert.NotContains(t, string(data), "@.")
+
+	records, err := zonefile.Parse(bytes.NewReader(data), "")
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "blog", records[0].Name)
+	assert.Equal(t, resources.DNSRecordTypeCNAME, records[0].Type)
+	assert.Equal(t, "example.com", records[0].Value, "@ should resolve to the zone apex")
+}
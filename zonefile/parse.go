@@ -0,0 +1,425 @@
+package zonefile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// token is a single field of a zone file entry
+type token struct {
+	text   string
+	quoted bool
+}
+
+// entry is a logical zone file line, with parentheses joining physical lines
+type entry struct {
+	line       int
+	blankOwner bool
+	tokens     []token
+}
+
+// Parse parses a BIND zone file into DNS record options. Record names are relative to origin,
+// with an empty name for the zone apex. When origin is empty the first $ORIGIN directive is used.
+// SOA records are skipped because Bunny.net manages them.
+func Parse(r io.Reader, origin string) ([]resources.AddDNSRecordOptions, error) {
+	entries, err := lex(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{zone: common.NormalizeDomain(origin)}
+	p.origin = p.zone
+
+	var records []resources.AddDNSRecordOptions
+	for _, e := range entries {
+		record, ok, err := p.parseEntry(e)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// parser holds the state carried between zone file entries
+type parser struct {
+	zone       string
+	origin     string
+	defaultTTL int32
+	lastOwner  string
+}
+
+// parseEntry parses a directive or a resource record
+func (p *parser) parseEntry(e entry) (resources.AddDNSRecordOptions, bool, error) {
+	fail := func(format string, args ...interface{}) (resources.AddDNSRecordOptions, bool, error) {
+		return resources.AddDNSRecordOptions{}, false, newParseError(e.line, format, args...)
+	}
+
+	first := e.tokens[0]
+	if !e.blankOwner && !first.quoted && strings.HasPrefix(first.text, "$") {
+		directive := strings.ToUpper(first.text)
+		if len(e.tokens) < 2 {
+			return fail("%s requires an argument", directive)
+		}
+		switch directive {
+		case "$ORIGIN":
+			origin, err := p.absolute(e.tokens[1].text)
+			if err != nil {
+				return fail("%v", err)
+			}
+			p.origin = origin
+			if p.zone == "" {
+				p.zone = origin
+			}
+		case "$TTL":
+			ttl, ok := parseTTL(e.tokens[1].text)
+			if !ok {
+				return fail("invalid TTL %q", e.tokens[1].text)
+			}
+			p.defaultTTL = ttl
+		default:
+			return fail("unsupported directive %s", directive)
+		}
+		return resources.AddDNSRecordOptions{}, false, nil
+	}
+
+	if p.origin == "" {
+		return fail("no origin: pass the zone domain or add an $ORIGIN directive")
+	}
+
+	fields := e.tokens
+	owner := p.lastOwner
+	if !e.blankOwner {
+		name, err := p.absolute(fields[0].text)
+		if err != nil {
+			return fail("%v", err)
+		}
+		owner = name
+		fields = fields[1:]
+	}
+	if owner == "" {
+		return fail("record has no owner name")
+	}
+	p.lastOwner = owner
+
+	// TTL and class may appear in either order before the type
+	ttl := p.defaultTTL
+prefix:
+	for len(fields) > 0 {
+		if value, ok := parseTTL(fields[0].text); ok {
+			ttl = value
+			fields = fields[1:]
+			continue
+		}
+		switch class := strings.ToUpper(fields[0].text); class {
+		case "IN":
+			fields = fields[1:]
+		case "CH", "HS", "CS":
+			return fail("unsupported class %s", class)
+		default:
+			break prefix
+		}
+	}
+	if len(fields) == 0 {
+		return fail("missing record type")
+	}
+
+	typeName := strings.ToUpper(fields[0].text)
+	data := fields[1:]
+	if typeName == "SOA" {
+		return resources.AddDNSRecordOptions{}, false, nil
+	}
+
+	recordType, ok := recordTypes[typeName]
+	if !ok {
+		return fail("unsupported record type %s", typeName)
+	}
+
+	name, err := p.relative(owner)
+	if err != nil {
+		return fail("%v", err)
+	}
+
+	record := resources.AddDNSRecordOptions{Type: recordType, Name: name, Ttl: ttl}
+	if err := p.parseData(&record, typeName, data); err != nil {
+		return fail("%s record: %v", typeName, err)
+	}
+
+	return record, true, nil
+}
+
+// parseData parses the type-specific fields of a record
+func (p *parser) parseData(record *resources.AddDNSRecordOptions, typeName string, data []token) error {
+	expect := func(n int) error {
+		if len(data) != n {
+			return fmt.Errorf("expected %d fields, got %d", n, len(data))
+		}
+		return nil
+	}
+	number := func(s string, bits int) (int64, error) {
+		n, err := strconv.ParseUint(s, 10, bits)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", s)
+		}
+		return int64(n), nil
+	}
+
+	switch record.Type {
+	case resources.DNSRecordTypeA, resources.DNSRecordTypeAAAA:
+		if err := expect(1); err != nil {
+			return err
+		}
+		addr, err := netip.ParseAddr(data[0].text)
+		if err != nil || addr.Is4() != (record.Type == resources.DNSRecordTypeA) {
+			return fmt.Errorf("invalid address %q", data[0].text)
+		}
+		record.Value = addr.String()
+
+	case resources.DNSRecordTypeCNAME, resources.DNSRecordTypeNS, resources.DNSRecordTypePTR:
+		if err := expect(1); err != nil {
+			return err
+		}
+		target, err := p.absolute(data[0].text)
+		if err != nil {
+			return err
+		}
+		record.Value = target
+
+	case resources.DNSRecordTypeMX:
+		if err := expect(2); err != nil {
+			return err
+		}
+		priority, err := number(data[0].text, 16)
+		if err != nil {
+			return err
+		}
+		target, err := p.absolute(data[1].text)
+		if err != nil {
+			return err
+		}
+		record.Priority = int32(priority)
+		record.Value = target
+
+	case resources.DNSRecordTypeSRV:
+		if err := expect(4); err != nil {
+			return err
+		}
+		var values [3]int64
+		for i := range values {
+			n, err := number(data[i].text, 16)
+			if err != nil {
+				return err
+			}
+			values[i] = n
+		}
+		target, err := p.absolute(data[3].text)
+		if err != nil {
+			return err
+		}
+		record.Priority = int32(values[0])
+		record.Weight = int32(values[1])
+		record.Port = int32(values[2])
+		record.Value = target
+
+	case resources.DNSRecordTypeCAA:
+		if err := expect(3); err != nil {
+			return err
+		}
+		flags, err := number(data[0].text, 8)
+		if err != nil {
+			return err
+		}
+		record.Flags = int(flags)
+		record.Tag = strings.ToLower(data[1].text)
+		record.Value = data[2].text
+
+	case resources.DNSRecordTypeTXT:
+		if len(data) == 0 {
+			return fmt.Errorf("missing text")
+		}
+		var text strings.Builder
+		for _, t := range data {
+			text.WriteString(t.text)
+		}
+		record.Value = text.String()
+	}
+
+	return nil
+}
+
+// absolute resolves a name against the current origin, returning it without a trailing dot
+func (p *parser) absolute(name string) (string, error) {
+	if name == "@" {
+		if p.origin == "" {
+			return "", fmt.Errorf("@ used without an origin")
+		}
+		return p.origin, nil
+	}
+	if strings.HasSuffix(name, ".") {
+		return common.NormalizeDomain(name), nil
+	}
+	if p.origin == "" {
+		return "", fmt.Errorf("relative name %q used without an origin", name)
+	}
+	return strings.ToLower(name) + "." + p.origin, nil
+}
+
+// relative converts an absolute owner name to a record name relative to the zone
+func (p *parser) relative(name string) (string, error) {
+	if name == p.zone {
+		return "", nil
+	}
+	if strings.HasSuffix(name, "."+p.zone) {
+		return strings.TrimSuffix(name, "."+p.zone), nil
+	}
+	return "", fmt.Errorf("name %q is outside of zone %q", name, p.zone)
+}
+
+// lex splits a zone file into entries, handling comments, quoted strings and parentheses
+func lex(r io.Reader) ([]entry, error) {
+	reader := bufio.NewReader(r)
+
+	var entries []entry
+	var current entry
+	var text strings.Builder
+	inToken, quoted := false, false
+	depth, line := 0, 1
+	atLineStart := true
+
+	endToken := func() {
+		if inToken {
+			current.tokens = append(current.tokens, token{text: text.String(), quoted: quoted})
+		}
+		text.Reset()
+		inToken, quoted = false, false
+	}
+	endEntry := func() {
+		endToken()
+		if len(current.tokens) > 0 {
+			entries = append(entries, current)
+		}
+		current = entry{}
+	}
+
+	for {
+		c, err := reader.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if atLineStart && depth == 0 {
+			current.line = line
+			current.blankOwner = c == ' ' || c == '\t'
+		}
+		atLineStart = false
+
+		switch c {
+		case '\n':
+			endToken()
+			if depth == 0 {
+				endEntry()
+			}
+			line++
+			atLineStart = true
+		case ' ', '\t', '\r':
+			endToken()
+		case ';':
+			endToken()
+			for {
+				next, err := reader.ReadByte()
+				if err != nil || next == '\n' {
+					if err == nil {
+						reader.UnreadByte()
+					}
+					break
+				}
+			}
+		case '(':
+			endToken()
+			depth++
+		case ')':
+			endToken()
+			if depth == 0 {
+				return nil, newParseError(line, "unbalanced parenthesis")
+			}
+			depth--
+		case '"':
+			endToken()
+			inToken, quoted = true, true
+			for {
+				next, err := reader.ReadByte()
+				if err != nil || next == '\n' {
+					return nil, newParseError(line, "unterminated quoted string")
+				}
+				if next == '"' {
+					break
+				}
+				if next == '\\' {
+					escaped, err := readEscape(reader)
+					if err != nil {
+						return nil, newParseError(line, "%v", err)
+					}
+					text.WriteByte(escaped)
+					continue
+				}
+				text.WriteByte(next)
+			}
+			endToken()
+		case '\\':
+			escaped, err := readEscape(reader)
+			if err != nil {
+				return nil, newParseError(line, "%v", err)
+			}
+			text.WriteByte(escaped)
+			inToken = true
+		default:
+			text.WriteByte(c)
+			inToken = true
+		}
+	}
+
+	if depth != 0 {
+		return nil, newParseError(line, "unbalanced parenthesis")
+	}
+	endEntry()
+
+	return entries, nil
+}
+
+// readEscape reads the character after a backslash, either a literal character or a \DDD decimal escape
+func readEscape(reader *bufio.Reader) (byte, error) {
+	c, err := reader.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("incomplete escape sequence")
+	}
+	if c < '0' || c > '9' {
+		return c, nil
+	}
+
+	digits := []byte{c}
+	for len(digits) < 3 {
+		next, err := reader.ReadByte()
+		if err != nil || next < '0' || next > '9' {
+			return 0, fmt.Errorf("invalid decimal escape sequence")
+		}
+		digits = append(digits, next)
+	}
+	n, err := strconv.Atoi(string(digits))
+	if err != nil || n > 255 {
+		return 0, fmt.Errorf("invalid decimal escape sequence")
+	}
+	return byte(n), nil
+}
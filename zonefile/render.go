@@ -0,0 +1,134 @@
+package zonefile
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/venom90/bunnynet-go/common"
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// Render renders the records of a DNS zone as canonical zone file text. Records are sorted by
+// name, type and value, and names are written relative to the zone origin. Records of Bunny-only
+// types cannot be represented in a zone file and are returned separately. Disabled records are
+// written as comments.
+func Render(zone *resources.DNSZone) ([]byte, []resources.DNSRecord) {
+	origin := common.NormalizeDomain(zone.Domain)
+
+	var records, skipped []resources.DNSRecord
+	for _, record := range zone.Records {
+		if _, ok := typeNames[record.Type]; !ok {
+			skipped = append(skipped, record)
+			continue
+		}
+		records = append(records, record)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.Name != b.Name {
+			return renderName(a.Name) < renderName(b.Name)
+		}
+		if a.Type != b.Type {
+			return typeNames[a.Type] < typeNames[b.Type]
+		}
+		return a.Value < b.Value
+	})
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "$ORIGIN %s.\n", origin)
+
+	w := tabwriter.NewWriter(&buf, 0, 8, 1, '\t', 0)
+	for _, record := range records {
+		ttl := ""
+		if record.Ttl > 0 {
+			ttl = strconv.Itoa(int(record.Ttl))
+		}
+		prefix := ""
+		if record.Disabled {
+			prefix = "; "
+		}
+		fmt.Fprintf(w, "%s%s\t%s\tIN\t%s\t%s\n", prefix, renderName(record.Name), ttl, typeNames[record.Type], renderData(record))
+	}
+	w.Flush()
+
+	return buf.Bytes(), skipped
+}
+
+// renderName returns the owner name of a record, using @ for the apex
+func renderName(name string) string {
+	if name == "" || name == "@" {
+		return "@"
+	}
+	return name
+}
+
+// renderData returns the type-specific fields of a record
+func renderData(record resources.DNSRecord) string {
+	switch record.Type {
+	case resources.DNSRecordTypeCNAME, resources.DNSRecordTypeNS, resources.DNSRecordTypePTR:
+		return renderTarget(record.Value)
+	case resources.DNSRecordTypeMX:
+		return fmt.Sprintf("%d %s", record.Priority, renderTarget(record.Value))
+	case resources.DNSRecordTypeSRV:
+		return fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, renderTarget(record.Value))
+	case resources.DNSRecordTypeCAA:
+		return fmt.Sprintf("%d %s %s", record.Flags, record.Tag, quote(record.Value))
+	case resources.DNSRecordTypeTXT:
+		return renderTXT(record.Value)
+	}
+	return record.Value
+}
+
+// renderTXT splits a TXT value into quoted character strings of at most 255 bytes
+func renderTXT(value string) string {
+	chunks := resources.SplitTXTValue(value)
+	if len(chunks) == 1 {
+		return quote(value)
+	}
+
+	for i, chunk := range chunks {
+		chunks[i] = quote(chunk)
+	}
+	return "( " + strings.Join(chunks, " ") + " )"
+}
+
+// quote quotes a character string, escaping quotes, backslashes and non-printable bytes
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c >= 0x7f:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// renderTarget returns the target name of a record, keeping @ so that it refers to the zone apex
+func renderTarget(value string) string {
+	if value == "@" {
+		return value
+	}
+	return fqdn(value)
+}
+
+// fqdn returns a domain name with a trailing dot
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
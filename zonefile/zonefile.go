@@ -0,0 +1,108 @@
+// Package zonefile converts between RFC 1035 (BIND) zone files and Bunny.net DNS records
+//
+// Parse turns a zone file into options for DNSZoneService.AddRecord, and Render turns a
+// DNSZone returned by DNSZoneService.Get back into zone file text. Records of types that only
+// exist on Bunny.net (Redirect, Flatten, PullZone and Script) have no zone file representation
+// and are reported separately by Render.
+package zonefile
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/venom90/bunnynet-go/resources"
+)
+
+// recordTypes maps zone file type mnemonics to DNS record types
+var recordTypes = map[string]resources.DNSRecordType{
+	"A":     resources.DNSRecordTypeA,
+	"AAAA":  resources.DNSRecordTypeAAAA,
+	"CNAME": resources.DNSRecordTypeCNAME,
+	"TXT":   resources.DNSRecordTypeTXT,
+	"MX":    resources.DNSRecordTypeMX,
+	"SRV":   resources.DNSRecordTypeSRV,
+	"CAA":   resources.DNSRecordTypeCAA,
+	"PTR":   resources.DNSRecordTypePTR,
+	"NS":    resources.DNSRecordTypeNS,
+}
+
+// typeNames maps DNS record types to zone file type mnemonics
+var typeNames = map[resources.DNSRecordType]string{}
+
+func init() {
+	for name, recordType := range recordTypes {
+		typeNames[recordType] = name
+	}
+}
+
+// IsBunnyOnly reports whether the record type only exists on Bunny.net and cannot be written to a zone file
+func IsBunnyOnly(recordType resources.DNSRecordType) bool {
+	switch recordType {
+	case resources.DNSRecordTypeRedirect, resources.DNSRecordTypeFlatten,
+		resources.DNSRecordTypePullZone, resources.DNSRecordTypeScript:
+		return true
+	}
+	return false
+}
+
+// ParseError describes a syntax error in a zone file
+type ParseError struct {
+	// Line is the line number where the error occurred, starting at 1
+	Line int
+
+	// Message describes the error
+	Message string
+}
+
+// Error implements the error interface
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("zone file line %d: %s", e.Line, e.Message)
+}
+
+// parseTTL parses a TTL in seconds or in BIND duration notation such as 1h30m
+func parseTTL(s string) (int32, bool) {
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return 0, false
+	}
+	if n, err := strconv.ParseUint(s, 10, 31); err == nil {
+		return int32(n), true
+	}
+
+	var total, current uint64
+	digits := false
+	for _, c := range strings.ToLower(s) {
+		if c >= '0' && c <= '9' {
+			current = current*10 + uint64(c-'0')
+			digits = true
+			continue
+		}
+		if !digits {
+			return 0, false
+		}
+		switch c {
+		case 's':
+			total += current
+		case 'm':
+			total += current * 60
+		case 'h':
+			total += current * 3600
+		case 'd':
+			total += current * 86400
+		case 'w':
+			total += current * 604800
+		default:
+			return 0, false
+		}
+		current, digits = 0, false
+	}
+	if digits || total > 1<<31-1 {
+		return 0, false
+	}
+	return int32(total), true
+}
+
+// newParseError creates a ParseError for the given line
+func newParseError(line int, format string, args ...interface{}) *ParseError {
+	return &ParseError{Line: line, Message: fmt.Sprintf(format, args...)}
+}